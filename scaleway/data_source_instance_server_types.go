@@ -0,0 +1,248 @@
+package scaleway
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayInstanceServerTypes() *schema.Resource {
+	zone := zoneSchema()
+	zone.ForceNew = false
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayInstanceServerTypesRead,
+		Schema: map[string]*schema.Schema{
+			"min_cpus": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "List only server types with at least this number of CPUs",
+			},
+			"min_ram": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "List only server types with at least this amount of RAM in bytes",
+			},
+			"min_gpus": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "List only server types with at least this number of GPUs",
+			},
+			"arch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only server types with this CPU architecture",
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.ArchX86_64.String(),
+					instance.ArchArm.String(),
+				}, false),
+			},
+			"max_hourly_price": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Description: "List only server types with an hourly price lower or equal to this price in Euro",
+			},
+			"available_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "List only server types that are not in shortage",
+			},
+			"server_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The server types matching the filters, from the cheapest to the most expensive",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"alt_names": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ram": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"arch": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"baremetal": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"volumes_min_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"volumes_max_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"l_ssd_volume_min_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"l_ssd_volume_max_size": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"internet_bandwidth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"internal_bandwidth": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ipv6_support": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"hourly_price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"monthly_price": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+						"availability": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"zone": zone,
+		},
+	}
+}
+
+func dataSourceScalewayInstanceServerTypesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.ListServersTypes(&instance.ListServersTypesRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	availabilities, err := instanceAPI.GetServerTypesAvailability(&instance.GetServerTypesAvailabilityRequest{
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, 0, len(res.Servers))
+	for name := range res.Servers {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if res.Servers[names[i]].HourlyPrice != res.Servers[names[j]].HourlyPrice {
+			return res.Servers[names[i]].HourlyPrice < res.Servers[names[j]].HourlyPrice
+		}
+		return names[i] < names[j]
+	})
+
+	minCPUs := d.Get("min_cpus").(int)
+	minRAM := d.Get("min_ram").(int)
+	minGPUs := d.Get("min_gpus").(int)
+	arch := d.Get("arch").(string)
+	maxHourlyPrice, hasMaxHourlyPrice := d.GetOk("max_hourly_price")
+	availableOnly := d.Get("available_only").(bool)
+
+	serverTypes := []map[string]interface{}(nil)
+	for _, name := range names {
+		serverType := res.Servers[name]
+
+		availability := instance.ServerTypesAvailabilityAvailable
+		if a, ok := availabilities.Servers[name]; ok {
+			availability = a.Availability
+		}
+
+		gpus := 0
+		if serverType.Gpu != nil {
+			gpus = int(*serverType.Gpu)
+		}
+
+		switch {
+		case int(serverType.Ncpus) < minCPUs,
+			int(serverType.RAM) < minRAM,
+			gpus < minGPUs,
+			arch != "" && serverType.Arch.String() != arch,
+			hasMaxHourlyPrice && float64(serverType.HourlyPrice) > maxHourlyPrice.(float64),
+			availableOnly && availability == instance.ServerTypesAvailabilityShortage:
+			continue
+		}
+
+		serverTypes = append(serverTypes, flattenInstanceServerType(name, serverType, availability, gpus))
+	}
+
+	d.SetId(zone.String())
+	_ = d.Set("zone", zone.String())
+	_ = d.Set("server_types", serverTypes)
+
+	return nil
+}
+
+func flattenInstanceServerType(name string, serverType *instance.ServerType, availability instance.ServerTypesAvailability, gpus int) map[string]interface{} {
+	flat := map[string]interface{}{
+		"name":          name,
+		"alt_names":     serverType.AltNames,
+		"cpus":          int(serverType.Ncpus),
+		"ram":           int(serverType.RAM),
+		"gpus":          gpus,
+		"arch":          serverType.Arch.String(),
+		"baremetal":     serverType.Baremetal,
+		"hourly_price":  float64(serverType.HourlyPrice),
+		"monthly_price": float64(serverType.MonthlyPrice),
+		"availability":  availability.String(),
+	}
+
+	if serverType.VolumesConstraint != nil {
+		flat["volumes_min_size"] = int(serverType.VolumesConstraint.MinSize)
+		flat["volumes_max_size"] = int(serverType.VolumesConstraint.MaxSize)
+	}
+
+	if serverType.PerVolumeConstraint != nil && serverType.PerVolumeConstraint.LSSD != nil {
+		flat["l_ssd_volume_min_size"] = int(serverType.PerVolumeConstraint.LSSD.MinSize)
+		flat["l_ssd_volume_max_size"] = int(serverType.PerVolumeConstraint.LSSD.MaxSize)
+	}
+
+	if serverType.Network != nil {
+		flat["ipv6_support"] = serverType.Network.IPv6Support
+		if serverType.Network.SumInternetBandwidth != nil {
+			flat["internet_bandwidth"] = int(*serverType.Network.SumInternetBandwidth)
+		}
+		if serverType.Network.SumInternalBandwidth != nil {
+			flat["internal_bandwidth"] = int(*serverType.Network.SumInternalBandwidth)
+		}
+	}
+
+	return flat
+}
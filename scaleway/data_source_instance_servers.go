@@ -0,0 +1,190 @@
+package scaleway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayInstanceServers() *schema.Resource {
+	zone := zoneSchema()
+	zone.ForceNew = false
+	zone.ConflictsWith = []string{"all_zones"}
+
+	projectID := projectIDSchema()
+	projectID.ForceNew = false
+	projectID.Computed = false
+	projectID.Description = "List only servers of this project ID"
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayInstanceServersRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only servers whose name starts with this prefix",
+			},
+			"tags": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "List only servers having all these tags",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only servers in this state: started, stopped or standby",
+				ValidateFunc: validation.StringInSlice([]string{
+					InstanceServerStateStarted,
+					InstanceServerStateStopped,
+					InstanceServerStateStandby,
+				}, false),
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only servers of this commercial type",
+			},
+			"all_zones": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "List servers in all zones",
+				ConflictsWith: []string{"zone"},
+			},
+			"servers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The servers matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"public_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private_ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"zone": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"project_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"zone":       zone,
+			"project_id": projectID,
+		},
+	}
+}
+
+func dataSourceScalewayInstanceServersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI := instance.NewAPI(meta.(*Meta).scwClient)
+
+	// The zone is only resolved when the servers are not listed in all zones, so that no default zone is required.
+	zones := scw.AllZones
+	id := "all"
+	if !d.Get("all_zones").(bool) {
+		zone, err := extractZone(d, meta.(*Meta))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		zones = []scw.Zone{zone}
+		id = zone.String()
+	}
+
+	req := &instance.ListServersRequest{
+		Project:        expandStringPtr(d.Get("project_id")),
+		Name:           expandStringPtr(d.Get("name_prefix")),
+		CommercialType: expandStringPtr(d.Get("type")),
+	}
+
+	if rawState, ok := d.GetOk("state"); ok {
+		state, err := serverStateExpand(rawState.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		req.State = &state
+	}
+
+	namePrefix := d.Get("name_prefix").(string)
+	tags := expandStrings(d.Get("tags"))
+
+	servers := []map[string]interface{}(nil)
+	for _, zone := range zones {
+		req.Zone = zone
+		res, err := instanceAPI.ListServers(req, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, server := range res.Servers {
+			// The API filters servers by name on a substring.
+			if !strings.HasPrefix(server.Name, namePrefix) || !serverHasTags(server, tags) {
+				continue
+			}
+
+			state, err := serverStateFlatten(server.State)
+			if err != nil {
+				state = server.State.String()
+			}
+
+			publicIP := ""
+			if server.PublicIP != nil && server.PublicIP.Address != nil {
+				publicIP = server.PublicIP.Address.String()
+			}
+
+			servers = append(servers, map[string]interface{}{
+				"id":         newZonedIDString(zone, server.ID),
+				"name":       server.Name,
+				"type":       server.CommercialType,
+				"state":      state,
+				"tags":       server.Tags,
+				"public_ip":  publicIP,
+				"private_ip": flattenStringPtr(server.PrivateIP),
+				"zone":       zone.String(),
+				"project_id": server.Project,
+			})
+		}
+	}
+
+	d.SetId(id)
+	_ = d.Set("servers", servers)
+
+	return nil
+}
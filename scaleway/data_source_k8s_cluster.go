@@ -17,6 +17,9 @@ func dataSourceScalewayK8SCluster() *schema.Resource {
 	// Set 'Optional' schema elements
 	addOptionalFieldsToSchema(dsSchema, "name", "region")
 	delete(dsSchema, "delete_additional_resources")
+	delete(dsSchema, "upgrade_pools")
+	delete(dsSchema, "upgrade_pools_exclude")
+	delete(dsSchema, "rotate_admin_token")
 
 	dsSchema["name"].ConflictsWith = []string{"cluster_id"}
 	dsSchema["cluster_id"] = &schema.Schema{
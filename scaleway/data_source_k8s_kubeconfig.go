@@ -0,0 +1,167 @@
+package scaleway
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayK8SKubeconfig() *schema.Resource {
+	region := regionSchema()
+	region.ForceNew = false
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayK8SKubeconfigRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the cluster",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"exec": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Render a kubeconfig using an exec credential plugin instead of the admin token",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The command returning the credentials",
+						},
+						"args": {
+							Type: schema.TypeList,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Description: "The arguments of the command",
+						},
+						"env": {
+							Type: schema.TypeMap,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Optional:    true,
+							Description: "The environment variables to set when running the command",
+						},
+						"api_version": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     k8sExecCredentialAPIVersion,
+							Description: "The API version of the ExecCredential returned by the command",
+						},
+					},
+				},
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The whole kubeconfig file",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kubernetes master URL",
+			},
+			"cluster_ca_certificate": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The kubernetes cluster CA certificate",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The kubernetes cluster admin token, empty when an exec credential plugin is used",
+			},
+			"region": region,
+		},
+	}
+}
+
+func dataSourceScalewayK8SKubeconfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, datasourceNewRegionalizedID(d.Get("cluster_id"), region))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kubeconfig, err := k8sAPI.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kubeconfigServer, err := kubeconfig.GetServer()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	kubeconfigCa, err := kubeconfig.GetCertificateAuthorityData()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configFile := string(kubeconfig.GetRaw())
+	token := ""
+	if _, ok := d.GetOk("exec"); ok {
+		configFile, err = k8sExecKubeconfig(kubeconfig, expandK8SKubeconfigExec(d.Get("exec")))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		token, err = kubeconfig.GetToken()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(newRegionalIDString(region, clusterID))
+	_ = d.Set("cluster_id", newRegionalIDString(region, clusterID))
+	_ = d.Set("config_file", configFile)
+	_ = d.Set("host", kubeconfigServer)
+	_ = d.Set("cluster_ca_certificate", kubeconfigCa)
+	_ = d.Set("token", token)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func expandK8SKubeconfigExec(raw interface{}) *k8sKubeconfigExec {
+	rawExec := raw.([]interface{})[0].(map[string]interface{})
+
+	exec := &k8sKubeconfigExec{
+		APIVersion: rawExec["api_version"].(string),
+		Command:    rawExec["command"].(string),
+		Args:       expandStrings(rawExec["args"]),
+	}
+
+	env := rawExec["env"].(map[string]interface{})
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exec.Env = append(exec.Env, k8sKubeconfigExecEnv{
+			Name:  name,
+			Value: env[name].(string),
+		})
+	}
+
+	return exec
+}
@@ -0,0 +1,197 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayK8SNodes() *schema.Resource {
+	region := regionSchema()
+	region.ForceNew = false
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayK8SNodesRead,
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the cluster of the nodes",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				AtLeastOneOf: []string{"cluster_id", "pool_id"},
+			},
+			"pool_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of the pool of the nodes",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				AtLeastOneOf: []string{"cluster_id", "pool_id"},
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The nodes of the cluster or of the pool",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the node",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the node",
+						},
+						"pool_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the pool of the node",
+						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone of the node, which is the zone of its pool",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the node",
+						},
+						"public_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv4 address of the node",
+						},
+						"public_ip_v6": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv6 address of the node",
+						},
+						"conditions": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "The conditions of the node",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time of the creation of the node",
+						},
+						"updated_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date and time of the last update of the node",
+						},
+					},
+				},
+			},
+			"region": region,
+		},
+	}
+}
+
+func dataSourceScalewayK8SNodesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &k8s.ListNodesRequest{
+		Region: region,
+	}
+	// The zone of the nodes is the zone of their pool.
+	poolZones := map[string]scw.Zone{}
+
+	if poolID, ok := d.GetOk("pool_id"); ok {
+		var id string
+		k8sAPI, region, id, err = k8sAPIWithRegionAndID(meta, datasourceNewRegionalizedID(poolID, region))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+			Region: region,
+			PoolID: id,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if clusterID, ok := d.GetOk("cluster_id"); ok && expandID(clusterID) != pool.ClusterID {
+			return diag.FromErr(fmt.Errorf("pool %s does not belong to the cluster %s", id, expandID(clusterID)))
+		}
+
+		poolZones[pool.ID] = pool.Zone
+		req.Region = region
+		req.ClusterID = pool.ClusterID
+		req.PoolID = &pool.ID
+		d.SetId(newRegionalIDString(region, pool.ID))
+		_ = d.Set("pool_id", newRegionalIDString(region, pool.ID))
+	} else {
+		var id string
+		k8sAPI, region, id, err = k8sAPIWithRegionAndID(meta, datasourceNewRegionalizedID(d.Get("cluster_id"), region))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		req.Region = region
+		req.ClusterID = id
+		d.SetId(newRegionalIDString(region, id))
+
+		pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+			Region:    region,
+			ClusterID: id,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, pool := range pools.Pools {
+			poolZones[pool.ID] = pool.Zone
+		}
+	}
+
+	res, err := k8sAPI.ListNodes(req, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("cluster_id", newRegionalIDString(region, req.ClusterID))
+	_ = d.Set("nodes", flattenK8SNodes(res.Nodes, poolZones))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func flattenK8SNodes(nodes []*k8s.Node, poolZones map[string]scw.Zone) []map[string]interface{} {
+	result := []map[string]interface{}(nil)
+	for _, node := range nodes {
+		n := map[string]interface{}{
+			"id":         newRegionalIDString(node.Region, node.ID),
+			"name":       node.Name,
+			"pool_id":    newRegionalIDString(node.Region, node.PoolID),
+			"zone":       poolZones[node.PoolID].String(),
+			"status":     node.Status.String(),
+			"conditions": node.Conditions,
+			"created_at": flattenTime(node.CreatedAt),
+			"updated_at": flattenTime(node.UpdatedAt),
+		}
+		if node.PublicIPV4 != nil && node.PublicIPV4.String() != "<nil>" {
+			n["public_ip"] = node.PublicIPV4.String()
+		}
+		if node.PublicIPV6 != nil && node.PublicIPV6.String() != "<nil>" {
+			n["public_ip_v6"] = node.PublicIPV6.String()
+		}
+		result = append(result, n)
+	}
+
+	return result
+}
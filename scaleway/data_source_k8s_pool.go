@@ -16,6 +16,8 @@ func dataSourceScalewayK8SPool() *schema.Resource {
 
 	// Set 'Optional' schema elements
 	addOptionalFieldsToSchema(dsSchema, "name", "region", "cluster_id", "size")
+	delete(dsSchema, "zones")
+	delete(dsSchema, "pool_ids")
 
 	dsSchema["name"].ConflictsWith = []string{"pool_id"}
 	dsSchema["cluster_id"].ConflictsWith = []string{"pool_id"}
@@ -0,0 +1,133 @@
+package scaleway
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func dataSourceScalewayK8SVersions() *schema.Resource {
+	region := regionSchema()
+	region.ForceNew = false
+
+	return &schema.Resource{
+		ReadContext: dataSourceScalewayK8SVersionsRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List only this full version (x.y.z) or the patch versions of this minor version (x.y)",
+			},
+			"versions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The available Kubernetes versions with their capabilities",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"available_cnis": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"available_ingresses": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"available_container_runtimes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"available_feature_gates": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"available_admission_plugins": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"available_kubelet_args": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"region": region,
+		},
+	}
+}
+
+func dataSourceScalewayK8SVersionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := k8sAPI.ListVersions(&k8s.ListVersionsRequest{
+		Region: region,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	filter, hasFilter := d.GetOk("version")
+	if hasFilter {
+		_, _, err = k8sParseMinorVersion(filter.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	versions := []map[string]interface{}(nil)
+	for _, version := range res.Versions {
+		if hasFilter && version.Name != filter.(string) && !strings.HasPrefix(version.Name, filter.(string)+".") {
+			continue
+		}
+
+		versions = append(versions, map[string]interface{}{
+			"name":                         version.Name,
+			"label":                        version.Label,
+			"available_cnis":               flattenK8SVersionCNIs(version),
+			"available_ingresses":          flattenK8SVersionIngresses(version),
+			"available_container_runtimes": flattenK8SVersionContainerRuntimes(version),
+			"available_feature_gates":      version.AvailableFeatureGates,
+			"available_admission_plugins":  version.AvailableAdmissionPlugins,
+			"available_kubelet_args":       flattenKubeletArgs(version.AvailableKubeletArgs),
+		})
+	}
+
+	d.SetId(region.String())
+	_ = d.Set("versions", versions)
+	_ = d.Set("region", region)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package scaleway
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"strings"
@@ -183,6 +184,20 @@ func extractZone(d terraformResourceData, meta *Meta) (scw.Zone, error) {
 	return "", ErrZoneNotFound
 }
 
+// extractZoneFromDiff is extractZone for a resource diff, where the zone may not be known yet.
+func extractZoneFromDiff(diff *schema.ResourceDiff, meta *Meta) (scw.Zone, error) {
+	if rawZone, exist := diff.GetOk("zone"); exist && diff.NewValueKnown("zone") {
+		return scw.ParseZone(rawZone.(string))
+	}
+
+	zone, exist := meta.scwClient.GetDefaultZone()
+	if exist {
+		return zone, nil
+	}
+
+	return "", ErrZoneNotFound
+}
+
 // ErrRegionNotFound is returned when no region can be detected
 var ErrRegionNotFound = fmt.Errorf("could not detect region")
 
@@ -203,6 +218,20 @@ func extractRegion(d terraformResourceData, meta *Meta) (scw.Region, error) {
 	return "", ErrRegionNotFound
 }
 
+// extractRegionFromDiff is extractRegion for a resource diff, where the region may not be known yet.
+func extractRegionFromDiff(diff *schema.ResourceDiff, meta *Meta) (scw.Region, error) {
+	if rawRegion, exist := diff.GetOk("region"); exist && diff.NewValueKnown("region") {
+		return scw.ParseRegion(rawRegion.(string))
+	}
+
+	region, exist := meta.scwClient.GetDefaultRegion()
+	if exist {
+		return region, nil
+	}
+
+	return "", ErrRegionNotFound
+}
+
 // isHTTPCodeError returns true if err is an http error with code statusCode
 func isHTTPCodeError(err error, statusCode int) bool {
 	if err == nil {
@@ -294,6 +323,9 @@ func newRandomName(prefix string) string {
 
 const gb uint64 = 1000 * 1000 * 1000
 
+// hoursPerMonth is the number of hours used to estimate a monthly price from an hourly price.
+const hoursPerMonth = 730
+
 func flattenTime(date *time.Time) interface{} {
 	if date != nil {
 		return date.Format(time.RFC3339)
@@ -371,6 +403,11 @@ func flattenIPPtr(ip *net.IP) interface{} {
 	return ip.String()
 }
 
+// flattenPrice rounds a price to the cent.
+func flattenPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
+
 func flattenStringPtr(s *string) interface{} {
 	if s == nil {
 		return ""
@@ -414,6 +451,27 @@ func expandInt32Ptr(data interface{}) *int32 {
 	return scw.Int32Ptr(int32(data.(int)))
 }
 
+// expandUint32Ptr returns nil for 0, the value of an unset optional field
+func expandUint32Ptr(data interface{}) *uint32 {
+	if data == nil || data == "" || data.(int) == 0 {
+		return nil
+	}
+	return scw.Uint32Ptr(uint32(data.(int)))
+}
+
+func expandTimePtr(i interface{}) *time.Time {
+	rawTime := i.(string)
+	if rawTime == "" {
+		return nil
+	}
+	parsedTime, err := time.Parse(time.RFC3339, rawTime)
+	if err != nil {
+		// We panic as this should never happen. Data from state should be validate using a validate func
+		panic(fmt.Errorf("%s could not be parsed: %v", rawTime, err)) // lintignore:R009
+	}
+	return &parsedTime
+}
+
 func expandIPNet(raw string) scw.IPNet {
 	if raw == "" {
 		return scw.IPNet{}
@@ -464,6 +522,18 @@ func diffSuppressFuncDuration(k, old, new string, d *schema.ResourceData) bool {
 	return d1 == d2
 }
 
+func diffSuppressFuncTimeRFC3339(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	t1, err1 := time.Parse(time.RFC3339, old)
+	t2, err2 := time.Parse(time.RFC3339, new)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return t1.Equal(t2)
+}
+
 func diffSuppressFuncIgnoreCase(k, old, new string, d *schema.ResourceData) bool {
 	return strings.EqualFold(old, new)
 }
@@ -1,11 +1,14 @@
 package scaleway
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	sdkValidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
 
 const (
@@ -99,3 +102,63 @@ func flattenBaremetalIPs(ips []*baremetal.IP) interface{} {
 	}
 	return flattendIPs
 }
+
+// flattenBaremetalOfferMonthlyPrice returns the monthly price in Euro of an offer.
+func flattenBaremetalOfferMonthlyPrice(offer *baremetal.Offer) float64 {
+	if offer.PricePerMonth == nil {
+		return 0
+	}
+	return flattenPrice(offer.PricePerMonth.ToFloat())
+}
+
+// getBaremetalOfferByName is GetOfferByName with a context.
+func getBaremetalOfferByName(ctx context.Context, baremetalAPI *baremetal.API, zone scw.Zone, offerName string) (*baremetal.Offer, error) {
+	res, err := baremetalAPI.ListOffers(&baremetal.ListOffersRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, offer := range res.Offers {
+		if offer.Name == offerName {
+			return offer, nil
+		}
+	}
+
+	return nil, fmt.Errorf("could not find the offer ID from name %s", offerName)
+}
+
+// customizeDiffBaremetalServerEstimatedMonthlyCost computes the estimated_monthly_cost of a server at plan time.
+func customizeDiffBaremetalServerEstimatedMonthlyCost(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("offer") {
+		return nil
+	}
+	if !diff.NewValueKnown("offer") {
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	zone, err := extractZoneFromDiff(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+
+	baremetalAPI := baremetal.NewAPI(meta.(*Meta).scwClient)
+	offerID := expandZonedID(diff.Get("offer"))
+
+	var offer *baremetal.Offer
+	if sdkValidation.IsUUID(offerID.ID) {
+		offer, err = baremetalAPI.GetOffer(&baremetal.GetOfferRequest{
+			Zone:    zone,
+			OfferID: offerID.ID,
+		}, scw.WithContext(ctx))
+	} else {
+		offer, err = getBaremetalOfferByName(ctx, baremetalAPI, zone, offerID.ID)
+	}
+	if err != nil {
+		l.Warningf("cannot estimate the monthly cost of the server: %s", err)
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	return diff.SetNew("estimated_monthly_cost", flattenBaremetalOfferMonthlyPrice(offer))
+}
@@ -1,15 +1,24 @@
 package scaleway
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
 
 const (
@@ -23,8 +32,28 @@ const (
 	defaultInstanceSecurityGroupRuleTimeout = 1 * time.Minute
 	defaultInstancePlacementGroupTimeout    = 1 * time.Minute
 	defaultInstanceIPTimeout                = 1 * time.Minute
+
+	// instanceUserDataKeyCloudInit is the user data key holding the cloud-init script.
+	instanceUserDataKeyCloudInit = "cloud-init"
+
+	instanceImageUpdatePolicyIgnore  = "ignore"
+	instanceImageUpdatePolicyWarn    = "warn"
+	instanceImageUpdatePolicyReplace = "replace"
+
+	instancePlacementGroupEnforcePolicyNone  = "none"
+	instancePlacementGroupEnforcePolicyFail  = "fail"
+	instancePlacementGroupEnforcePolicyRetry = "retry"
 )
 
+// instancePlacementGroupMaxServers is the number of servers up to which a placement group policy can be respected, by policy type.
+var instancePlacementGroupMaxServers = map[instance.PlacementGroupPolicyType]int{
+	instance.PlacementGroupPolicyTypeLowLatency:      20,
+	instance.PlacementGroupPolicyTypeMaxAvailability: 20,
+}
+
+// instanceSecurityGroupLocks holds a mutex per security group, see lockInstanceSecurityGroup.
+var instanceSecurityGroupLocks sync.Map
+
 // instanceAPIWithZone returns a new instance API and the zone for a Create request
 func instanceAPIWithZone(d *schema.ResourceData, m interface{}) (*instance.API, scw.Zone, error) {
 	meta := m.(*Meta)
@@ -148,16 +177,14 @@ func reachState(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, s
 }
 
 // getServerType is a util to get a instance.ServerType by its commercialType
-func getServerType(apiInstance *instance.API, zone scw.Zone, commercialType string) *instance.ServerType {
+func getServerType(ctx context.Context, meta interface{}, zone scw.Zone, commercialType string) *instance.ServerType {
 	serverType := (*instance.ServerType)(nil)
 
-	serverTypesRes, err := apiInstance.ListServersTypes(&instance.ListServersTypesRequest{
-		Zone: zone,
-	})
+	serverTypes, err := listInstanceServerTypes(ctx, meta.(*Meta), zone)
 	if err != nil {
 		l.Warningf("cannot get server types: %s", err)
 	} else {
-		serverType = serverTypesRes.Servers[commercialType]
+		serverType = serverTypes[commercialType]
 		if serverType == nil {
 			l.Warningf("unrecognized server type: %s", commercialType)
 		}
@@ -225,3 +252,606 @@ func sanitizeVolumeMap(serverName string, volumes map[string]*instance.VolumeTem
 
 	return m
 }
+
+// normalizeUserData normalizes line endings and trailing newlines of a user data value.
+//
+// Editors and the console may store scripts with CRLF line endings or without the
+// trailing newline, which would otherwise produce diffs on identical scripts.
+func normalizeUserData(value string) string {
+	return strings.TrimRight(strings.Replace(value, "\r\n", "\n", -1), "\n")
+}
+
+// diffSuppressFuncUserData suppresses diffs between user data values that only differ by line endings.
+func diffSuppressFuncUserData(k, old, new string, d *schema.ResourceData) bool {
+	return normalizeUserData(old) == normalizeUserData(new)
+}
+
+// expandServerUserData returns the user data of a server, including its cloud init script.
+func expandServerUserData(d *schema.ResourceData) map[string]io.Reader {
+	userData := make(map[string]io.Reader)
+
+	if rawUserData, ok := d.GetOk("user_data"); ok {
+		for key, value := range rawUserData.(map[string]interface{}) {
+			userData[key] = bytes.NewBufferString(value.(string))
+		}
+	}
+
+	// cloud init script is set in user data
+	if cloudInit, ok := d.GetOk("cloud_init"); ok {
+		userData[instanceUserDataKeyCloudInit] = bytes.NewBufferString(cloudInit.(string))
+	}
+
+	return userData
+}
+
+// getLocalImageIDByLabel resolves a marketplace image label to the local image ID for a given commercial type.
+func getLocalImageIDByLabel(ctx context.Context, meta interface{}, zone scw.Zone, commercialType string, label string) (string, error) {
+	marketPlaceAPI := marketplace.NewAPI(meta.(*Meta).scwClient)
+	return marketPlaceAPI.GetLocalImageIDByLabel(&marketplace.GetLocalImageIDByLabelRequest{
+		CommercialType: commercialType,
+		Zone:           zone,
+		ImageLabel:     label,
+	}, scw.WithContext(ctx))
+}
+
+// customizeDiffInstanceServerImage re-resolves the image label of a server at plan time
+// and replaces the server when the label now points to a newer image and image_update_policy is set to replace.
+// The warn policy is handled when the server is read.
+func customizeDiffInstanceServerImage(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.HasChange("image") || diff.HasChange("type") {
+		return nil
+	}
+	if diff.Get("image_update_policy").(string) != instanceImageUpdatePolicyReplace {
+		return nil
+	}
+
+	imageLabel := expandZonedID(diff.Get("image")).ID
+	if scwvalidation.IsUUID(imageLabel) {
+		return nil
+	}
+
+	zone, _, err := parseZonedID(diff.Id())
+	if err != nil {
+		return err
+	}
+
+	latestImageID, err := getLocalImageIDByLabel(ctx, meta, zone, diff.Get("type").(string), imageLabel)
+	if err != nil {
+		return fmt.Errorf("could not get image '%s': %s", newZonedID(zone, imageLabel), err)
+	}
+
+	if latestImageID == expandZonedID(diff.Get("image_id")).ID {
+		return nil
+	}
+
+	err = diff.SetNew("image_id", newZonedID(zone, latestImageID).String())
+	if err != nil {
+		return err
+	}
+	return diff.ForceNew("image_id")
+}
+
+// lockInstanceSecurityGroup locks the rules of a security group.
+//
+// Rules of a security group may be edited by several resources at the same time.
+// As rule positions are computed by the API, concurrent edits must be serialized.
+func lockInstanceSecurityGroup(zone scw.Zone, securityGroupID string) *sync.Mutex {
+	v, _ := instanceSecurityGroupLocks.LoadOrStore(newZonedIDString(zone, securityGroupID), &sync.Mutex{})
+	mutex := v.(*sync.Mutex)
+	mutex.Lock()
+	return mutex
+}
+
+// securityGroupRuleSources holds the servers used to resolve the sources of security group rules.
+type securityGroupRuleSources struct {
+	servers []*instance.Server
+}
+
+// listSecurityGroupRuleSources lists the servers of a zone when a security group rule has a source.
+func listSecurityGroupRuleSources(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, d *schema.ResourceData) (*securityGroupRuleSources, error) {
+	sources := &securityGroupRuleSources{}
+
+	hasSource := false
+	for _, key := range []string{"inbound_rule", "outbound_rule", "inbound_rule_set", "outbound_rule_set"} {
+		rawRules := d.Get(key)
+		if set, ok := rawRules.(*schema.Set); ok {
+			rawRules = set.List()
+		}
+		for _, rawRule := range rawRules.([]interface{}) {
+			hasSource = hasSource || securityGroupRuleHasSource(rawRule.(map[string]interface{}))
+		}
+	}
+	if !hasSource {
+		return sources, nil
+	}
+
+	res, err := instanceAPI.ListServers(&instance.ListServersRequest{
+		Zone: zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	sources.servers = res.Servers
+
+	return sources, nil
+}
+
+// securityGroupRuleHasSource returns true if a state rule references a security group or server tags.
+func securityGroupRuleHasSource(rawRule map[string]interface{}) bool {
+	sourceSecurityGroupID, _ := rawRule["source_security_group_id"].(string)
+	sourceServerTags, _ := rawRule["source_server_tags"].([]interface{})
+	return sourceSecurityGroupID != "" || len(sourceServerTags) > 0
+}
+
+// ipRanges returns the sorted private and public ips of the servers matching the sources of a state rule.
+func (s *securityGroupRuleSources) ipRanges(rawRule map[string]interface{}) []interface{} {
+	sourceSecurityGroupID, _ := rawRule["source_security_group_id"].(string)
+	sourceSecurityGroupID = expandID(sourceSecurityGroupID)
+	sourceServerTags, _ := rawRule["source_server_tags"].([]interface{})
+
+	ips := map[string]bool{}
+	for _, server := range s.servers {
+		if sourceSecurityGroupID != "" && (server.SecurityGroup == nil || server.SecurityGroup.ID != sourceSecurityGroupID) {
+			continue
+		}
+		if !serverHasTags(server, expandStrings(sourceServerTags)) {
+			continue
+		}
+		if server.PrivateIP != nil && *server.PrivateIP != "" {
+			ips[*server.PrivateIP+"/32"] = true
+		}
+		if server.PublicIP != nil && server.PublicIP.Address != nil {
+			ips[server.PublicIP.Address.String()+"/32"] = true
+		}
+	}
+
+	sortedIPs := make([]string, 0, len(ips))
+	for ip := range ips {
+		sortedIPs = append(sortedIPs, ip)
+	}
+	sort.Strings(sortedIPs)
+
+	ipRanges := make([]interface{}, 0, len(sortedIPs))
+	for _, ip := range sortedIPs {
+		ipRanges = append(ipRanges, ip)
+	}
+	return ipRanges
+}
+
+// expand transforms a state rule to the api ones, resolving its sources to the ips of the matching servers.
+//
+// A rule whose sources match no server does not produce any api rule: expandAll refuses such rules.
+func (s *securityGroupRuleSources) expand(i interface{}) ([]*instance.SecurityGroupRule, error) {
+	rawRule := i.(map[string]interface{})
+	if !securityGroupRuleHasSource(rawRule) {
+		return securityGroupRuleExpand(rawRule)
+	}
+
+	ipRanges := s.ipRanges(rawRule)
+	if len(ipRanges) == 0 {
+		return nil, nil
+	}
+
+	resolvedRule := make(map[string]interface{}, len(rawRule))
+	for key, value := range rawRule {
+		resolvedRule[key] = value
+	}
+	resolvedRule["ip_ranges"] = ipRanges
+
+	return securityGroupRuleExpand(resolvedRule)
+}
+
+// expandAll transforms a list of state rules to the api ones.
+// It returns an error when the sources of a rule match no server.
+func (s *securityGroupRuleSources) expandAll(rawRules []interface{}) ([]*instance.SecurityGroupRule, error) {
+	rules := []*instance.SecurityGroupRule(nil)
+	for _, rawRule := range rawRules {
+		expandedRules, err := s.expand(rawRule)
+		if err != nil {
+			return nil, err
+		}
+		if len(expandedRules) == 0 {
+			rule := rawRule.(map[string]interface{})
+			return nil, fmt.Errorf("no server matches the sources of a rule (source_security_group_id: %q, source_server_tags: %v)", rule["source_security_group_id"], rule["source_server_tags"])
+		}
+		rules = append(rules, expandedRules...)
+	}
+	return rules, nil
+}
+
+// securityGroupRuleIPRange returns the ip range of a single ip, with the full prefix length of its address family.
+func securityGroupRuleIPRange(ip string) string {
+	if parsedIP := net.ParseIP(ip); parsedIP != nil && parsedIP.To4() == nil {
+		return ip + "/128"
+	}
+	return ip + "/32"
+}
+
+// securityGroupRuleIPRangeIsIP returns true when an ip range holds the given ip only.
+func securityGroupRuleIPRangeIsIP(ipRange string, ip string) bool {
+	rangeIP, ipNet, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return false
+	}
+	ones, bits := ipNet.Mask.Size()
+	return ones == bits && rangeIP.Equal(net.ParseIP(ip))
+}
+
+// parseSecurityGroupRulePortRange parses a port range of the form N-M.
+func parseSecurityGroupRulePortRange(portRange string) (uint32, uint32, error) {
+	parts := strings.Split(portRange, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid port_range %q: expected format N-M", portRange)
+	}
+	portFrom, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port_range %q: %s", portRange, err)
+	}
+	portTo, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid port_range %q: %s", portRange, err)
+	}
+	return uint32(portFrom), uint32(portTo), nil
+}
+
+// validateSecurityGroupRulePortRange accepts only port ranges N-M with 1 <= N <= M <= 65535.
+func validateSecurityGroupRulePortRange() schema.SchemaValidateFunc {
+	return func(i interface{}, s string) (strings []string, errors []error) {
+		str, isStr := i.(string)
+		if !isStr {
+			return nil, []error{fmt.Errorf("%v is not a string", i)}
+		}
+		portFrom, portTo, err := parseSecurityGroupRulePortRange(str)
+		if err != nil {
+			return nil, []error{err}
+		}
+		if portFrom < 1 || portTo > 65535 || portFrom > portTo {
+			return nil, []error{fmt.Errorf("invalid port_range %q: expected N-M with 1 <= N <= M <= 65535", str)}
+		}
+		return nil, nil
+	}
+}
+
+// customizeDiffSecurityGroupRules refuses rules whose ips are set in several ways:
+// ip_ranges conflicts with ip and ip_range, and sources conflict with all of them as the ips of a rule are computed
+// from its sources.
+func customizeDiffSecurityGroupRules(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range []string{"inbound_rule", "outbound_rule", "inbound_rule_set", "outbound_rule_set"} {
+		rawRules := diff.Get(key)
+		if set, ok := rawRules.(*schema.Set); ok {
+			rawRules = set.List()
+		}
+		for _, rawRule := range rawRules.([]interface{}) {
+			rule := rawRule.(map[string]interface{})
+			ipRanges, _ := rule["ip_ranges"].([]interface{})
+			if len(ipRanges) > 0 && (rule["ip"].(string) != "" || rule["ip_range"].(string) != "") {
+				return fmt.Errorf("%s: ip_ranges conflicts with ip and ip_range", key)
+			}
+			if !securityGroupRuleHasSource(rule) {
+				continue
+			}
+			if rule["ip"].(string) != "" || rule["ip_range"].(string) != "" || len(ipRanges) > 0 {
+				return fmt.Errorf("%s: source_security_group_id and source_server_tags conflict with ip, ip_range and ip_ranges", key)
+			}
+		}
+	}
+	return nil
+}
+
+// serverHasTags returns true if the server has all the given tags.
+func serverHasTags(server *instance.Server, tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, serverTag := range server.Tags {
+			if serverTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// withServerStoppedForLocalVolume runs f on a stopped server when the volume is a local volume.
+// Local volumes can only be attached to or detached from a stopped server: a running server is stopped
+// and started again once f is done.
+func withServerStoppedForLocalVolume(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string, volume *instance.Volume, f func() error) error {
+	if volume.VolumeType != instance.VolumeVolumeTypeLSSD {
+		return f()
+	}
+
+	res, err := instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if res.Server.State != instance.ServerStateRunning {
+		return f()
+	}
+
+	err = reachState(ctx, instanceAPI, zone, serverID, instance.ServerStateStopped)
+	if err != nil {
+		return err
+	}
+
+	err = f()
+	if err != nil {
+		return err
+	}
+
+	return reachState(ctx, instanceAPI, zone, serverID, instance.ServerStateRunning)
+}
+
+// isPlacementGroupPolicyEnforced returns true when an enforce policy requires the placement group policy to be checked.
+func isPlacementGroupPolicyEnforced(enforcePolicy string) bool {
+	return enforcePolicy == instancePlacementGroupEnforcePolicyFail || enforcePolicy == instancePlacementGroupEnforcePolicyRetry
+}
+
+// listPlacementGroupServers returns the servers of a placement group.
+func listPlacementGroupServers(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, placementGroupID string) ([]*instance.PlacementGroupServer, error) {
+	res, err := instanceAPI.GetPlacementGroupServers(&instance.GetPlacementGroupServersRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return res.Servers, nil
+}
+
+// filterPlacementGroupUnrespectedServers returns the servers for which the placement group policy is not respected.
+func filterPlacementGroupUnrespectedServers(servers []*instance.PlacementGroupServer) []*instance.PlacementGroupServer {
+	var unrespectedServers []*instance.PlacementGroupServer
+	for _, server := range servers {
+		if !server.PolicyRespected {
+			unrespectedServers = append(unrespectedServers, server)
+		}
+	}
+	return unrespectedServers
+}
+
+// listPlacementGroupUnrespectedServers returns the servers of a placement group for which the policy is not respected.
+func listPlacementGroupUnrespectedServers(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, placementGroupID string) ([]*instance.PlacementGroupServer, error) {
+	servers, err := listPlacementGroupServers(ctx, instanceAPI, zone, placementGroupID)
+	if err != nil {
+		return nil, err
+	}
+	return filterPlacementGroupUnrespectedServers(servers), nil
+}
+
+// placementGroupHasTooManyServers returns true when a placement group holds more servers than its policy can be respected for.
+func placementGroupHasTooManyServers(policyType instance.PlacementGroupPolicyType, serverCount int) bool {
+	maxServers, ok := instancePlacementGroupMaxServers[policyType]
+	return ok && serverCount > maxServers
+}
+
+// placementGroupServersDiagnostics warns when a placement group holds more servers than its policy can be respected for.
+func placementGroupServersDiagnostics(placementGroup *instance.PlacementGroup, servers []*instance.PlacementGroupServer) diag.Diagnostics {
+	if !placementGroupHasTooManyServers(placementGroup.PolicyType, len(servers)) {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("placement group %s has too many servers for its policy to be respected", placementGroup.Name),
+		Detail:   fmt.Sprintf("placement group %s has %d servers, its %s policy can only be respected for up to %d servers", placementGroup.ID, len(servers), placementGroup.PolicyType, instancePlacementGroupMaxServers[placementGroup.PolicyType]),
+	}}
+}
+
+// enforcePlacementGroupPolicy fails when the policy of a placement group is not respected for all its servers.
+// With the retry policy, running servers for which the policy is not respected are stopped and started again
+// so that they are placed again, before checking the policy one last time.
+func enforcePlacementGroupPolicy(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, placementGroupID string, enforcePolicy string) error {
+	servers, err := listPlacementGroupUnrespectedServers(ctx, instanceAPI, zone, placementGroupID)
+	if err != nil {
+		return err
+	}
+
+	if len(servers) > 0 && enforcePolicy == instancePlacementGroupEnforcePolicyRetry {
+		for _, server := range servers {
+			res, err := instanceAPI.GetServer(&instance.GetServerRequest{
+				Zone:     zone,
+				ServerID: server.ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			if res.Server.State != instance.ServerStateRunning {
+				continue
+			}
+
+			l.Debugf("placement group %s policy is not respected for server %s, stopping and starting it", placementGroupID, server.ID)
+			err = restartInstanceServer(ctx, instanceAPI, zone, server.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		servers, err = listPlacementGroupUnrespectedServers(ctx, instanceAPI, zone, placementGroupID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(servers) > 0 {
+		names := []string(nil)
+		for _, server := range servers {
+			names = append(names, server.Name)
+		}
+		return fmt.Errorf("placement group %s policy is not respected for servers: %s", placementGroupID, strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// restartInstanceServer stops and starts a server, so that it is placed again.
+func restartInstanceServer(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string) error {
+	err := reachState(ctx, instanceAPI, zone, serverID, instance.ServerStateStopped)
+	if err != nil {
+		return err
+	}
+	return reachState(ctx, instanceAPI, zone, serverID, instance.ServerStateRunning)
+}
+
+// enforceInstanceServerPlacementGroupPolicy enforces the policy of a placement group for one of its servers.
+//
+// Servers join placement groups after the placement group is created or updated, so the placement group cannot enforce
+// its policy for them in the same run: the policy is enforced by the servers instead, with their
+// placement_group_enforce_policy.
+func enforceInstanceServerPlacementGroupPolicy(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, serverID string, placementGroupID string, enforcePolicy string) error {
+	if !isPlacementGroupPolicyEnforced(enforcePolicy) {
+		return nil
+	}
+
+	res, err := instanceAPI.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if res.Server.State != instance.ServerStateRunning || res.Server.PlacementGroup == nil || res.Server.PlacementGroup.PolicyRespected {
+		return nil
+	}
+
+	if enforcePolicy == instancePlacementGroupEnforcePolicyRetry {
+		l.Debugf("placement group %s policy is not respected for server %s, stopping and starting it", placementGroupID, serverID)
+		err = restartInstanceServer(ctx, instanceAPI, zone, serverID)
+		if err != nil {
+			return err
+		}
+
+		res, err = instanceAPI.GetServer(&instance.GetServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if res.Server.PlacementGroup == nil || res.Server.PlacementGroup.PolicyRespected {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("placement group %s policy is not respected for server %s", placementGroupID, res.Server.Name)
+}
+
+// customizeDiffInstanceServerPlacementGroup checks the placement group of a server at plan time.
+//
+// A warning is logged when a server joining a placement group makes it hold more servers than its policy can be
+// respected for, whatever the placement_group_enforce_policy. A CustomizeDiff cannot return warnings, so the check is
+// best effort: a placement group that cannot be fetched is only logged.
+//
+// An update is planned for the servers for which the policy is not respected only with the retry enforce policy and
+// when the placement group does not hold too many servers, as the apply cannot enforce the policy otherwise.
+// In the other cases, the policy violation is reported as a warning when the server is read.
+func customizeDiffInstanceServerPlacementGroup(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("placement_group_id") || diff.Get("placement_group_id").(string) == "" {
+		return nil
+	}
+	isJoining := diff.Id() == "" || diff.HasChange("placement_group_id")
+	needsRetry := !isJoining &&
+		diff.Get("placement_group_enforce_policy").(string) == instancePlacementGroupEnforcePolicyRetry &&
+		!diff.Get("placement_group_policy_respected").(bool)
+	if !isJoining && !needsRetry {
+		return nil
+	}
+
+	zone, err := extractZoneFromDiff(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+	placementGroupID := expandZonedID(diff.Get("placement_group_id")).ID
+	instanceAPI := instance.NewAPI(meta.(*Meta).scwClient)
+
+	res, err := instanceAPI.GetPlacementGroup(&instance.GetPlacementGroupRequest{
+		Zone:             zone,
+		PlacementGroupID: placementGroupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		l.Warningf("cannot check the servers of placement group %s: %s", placementGroupID, err)
+		return nil
+	}
+	servers, err := listPlacementGroupServers(ctx, instanceAPI, zone, placementGroupID)
+	if err != nil {
+		l.Warningf("cannot check the servers of placement group %s: %s", placementGroupID, err)
+		return nil
+	}
+
+	if isJoining {
+		if placementGroupHasTooManyServers(res.PlacementGroup.PolicyType, len(servers)+1) {
+			l.Warningf("placement group %s will have %d servers, its %s policy can only be respected for up to %d servers", placementGroupID, len(servers)+1, res.PlacementGroup.PolicyType, instancePlacementGroupMaxServers[res.PlacementGroup.PolicyType])
+		}
+		return nil
+	}
+
+	if placementGroupHasTooManyServers(res.PlacementGroup.PolicyType, len(servers)) {
+		return nil
+	}
+	return diff.SetNew("placement_group_policy_respected", true)
+}
+
+// listInstanceServerTypes returns the server types of a zone by commercial type.
+// They are cached in meta, as they are needed by every plan and every read of the priced resources.
+// All the server types of a zone are returned in a single page.
+func listInstanceServerTypes(ctx context.Context, meta *Meta, zone scw.Zone) (map[string]*instance.ServerType, error) {
+	if serverTypes, ok := meta.instanceServerTypes.Load(zone); ok {
+		return serverTypes.(map[string]*instance.ServerType), nil
+	}
+
+	res, err := instance.NewAPI(meta.scwClient).ListServersTypes(&instance.ListServersTypesRequest{
+		Zone: zone,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	meta.instanceServerTypes.Store(zone, res.Servers)
+	return res.Servers, nil
+}
+
+// getInstanceServerTypeMonthlyPrice returns the estimated monthly price in Euro of a commercial type.
+// Commercial types are matched ignoring case and hyphens, as Kubernetes node types are written gp1_xs.
+func getInstanceServerTypeMonthlyPrice(ctx context.Context, meta interface{}, zone scw.Zone, commercialType string) (float64, error) {
+	serverTypes, err := listInstanceServerTypes(ctx, meta.(*Meta), zone)
+	if err != nil {
+		return 0, err
+	}
+
+	serverType := serverTypes[strings.ToUpper(strings.ReplaceAll(commercialType, "_", "-"))]
+	if serverType == nil {
+		return 0, fmt.Errorf("unrecognized server type: %s", commercialType)
+	}
+
+	if serverType.MonthlyPrice == 0 {
+		return flattenPrice(float64(serverType.HourlyPrice) * hoursPerMonth), nil
+	}
+	return flattenPrice(float64(serverType.MonthlyPrice)), nil
+}
+
+// customizeDiffInstanceServerEstimatedMonthlyCost computes the estimated_monthly_cost of a server at plan time.
+func customizeDiffInstanceServerEstimatedMonthlyCost(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("type") {
+		return nil
+	}
+	if !diff.NewValueKnown("type") {
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	zone, err := extractZoneFromDiff(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+
+	price, err := getInstanceServerTypeMonthlyPrice(ctx, meta, zone, diff.Get("type").(string))
+	if err != nil {
+		l.Warningf("cannot estimate the monthly cost of the server: %s", err)
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	return diff.SetNew("estimated_monthly_cost", price)
+}
@@ -0,0 +1,187 @@
+package scaleway
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeUserData(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  "",
+		},
+		{
+			name:  "trailing newlines",
+			value: "#cloud-config\napt_update: true\n\n",
+			want:  "#cloud-config\napt_update: true",
+		},
+		{
+			name:  "crlf line endings",
+			value: "#cloud-config\r\napt_update: true\r\n",
+			want:  "#cloud-config\napt_update: true",
+		},
+		{
+			name:  "inner blank lines are kept",
+			value: "#cloud-config\n\napt_update: true",
+			want:  "#cloud-config\n\napt_update: true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeUserData(tt.value))
+		})
+	}
+}
+
+func TestSecurityGroupRuleSourcesExpand(t *testing.T) {
+	sources := &securityGroupRuleSources{
+		servers: []*instance.Server{
+			{
+				Tags:          []string{"web", "prod"},
+				PrivateIP:     scw.StringPtr("10.1.0.2"),
+				PublicIP:      &instance.ServerIP{Address: net.ParseIP("51.15.0.2")},
+				SecurityGroup: &instance.SecurityGroupSummary{ID: "11111111-1111-1111-1111-111111111111"},
+			},
+			{
+				Tags:          []string{"web"},
+				PrivateIP:     scw.StringPtr("10.1.0.1"),
+				SecurityGroup: &instance.SecurityGroupSummary{ID: "11111111-1111-1111-1111-111111111111"},
+			},
+			{
+				Tags:          []string{"db", "prod"},
+				PrivateIP:     scw.StringPtr("10.1.0.3"),
+				SecurityGroup: &instance.SecurityGroupSummary{ID: "22222222-2222-2222-2222-222222222222"},
+			},
+		},
+	}
+
+	rawRule := func(securityGroupID string, tags ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"action":                   "accept",
+			"protocol":                 "TCP",
+			"port":                     5432,
+			"port_range":               "",
+			"ip":                       "",
+			"ip_range":                 "",
+			"ip_ranges":                []interface{}{},
+			"source_security_group_id": securityGroupID,
+			"source_server_tags":       tags,
+		}
+	}
+
+	ipRanges := func(rules []*instance.SecurityGroupRule) []string {
+		res := []string(nil)
+		for _, rule := range rules {
+			res = append(res, flattenIPNet(rule.IPRange))
+		}
+		return res
+	}
+
+	rules, err := sources.expand(rawRule("fr-par-1/11111111-1111-1111-1111-111111111111"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.1.0.1/32", "10.1.0.2/32", "51.15.0.2/32"}, ipRanges(rules))
+
+	rules, err = sources.expand(rawRule("", "prod"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.1.0.2/32", "10.1.0.3/32", "51.15.0.2/32"}, ipRanges(rules))
+
+	rules, err = sources.expand(rawRule("22222222-2222-2222-2222-222222222222", "web"))
+	assert.NoError(t, err)
+	assert.Len(t, rules, 0)
+
+	// Rules without sources are expanded as is.
+	rules, err = sources.expand(rawRule(""))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.0.0.0/0"}, ipRanges(rules))
+
+	rules, err = sources.expandAll([]interface{}{rawRule(""), rawRule("", "prod")})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0.0.0.0/0", "10.1.0.2/32", "10.1.0.3/32", "51.15.0.2/32"}, ipRanges(rules))
+
+	// Rules whose sources match no server are refused.
+	_, err = sources.expandAll([]interface{}{rawRule(""), rawRule("22222222-2222-2222-2222-222222222222", "web")})
+	assert.Error(t, err)
+
+	// Rules whose sources match no server are not kept on read.
+	list, set, err := securityGroupRulesCollapse(nil, []interface{}{rawRule("", "unknown")}, []interface{}{rawRule("", "unknown")}, sources.expand)
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+	assert.Len(t, set, 0)
+}
+
+func TestSecurityGroupRuleIPRange(t *testing.T) {
+	assert.Equal(t, "1.1.1.1/32", securityGroupRuleIPRange("1.1.1.1"))
+	assert.Equal(t, "2001:db8::1/128", securityGroupRuleIPRange("2001:db8::1"))
+
+	assert.True(t, securityGroupRuleIPRangeIsIP("1.1.1.1/32", "1.1.1.1"))
+	assert.True(t, securityGroupRuleIPRangeIsIP("2001:db8::1/128", "2001:DB8:0::1"))
+	assert.False(t, securityGroupRuleIPRangeIsIP("2001:db8::/64", "2001:db8::"))
+	assert.False(t, securityGroupRuleIPRangeIsIP("1.1.1.1/32", "1.1.1.2"))
+}
+
+func TestPlacementGroupServersDiagnostics(t *testing.T) {
+	newServers := func(count int) []*instance.PlacementGroupServer {
+		servers := []*instance.PlacementGroupServer(nil)
+		for i := 0; i < count; i++ {
+			servers = append(servers, &instance.PlacementGroupServer{PolicyRespected: true})
+		}
+		return servers
+	}
+
+	for policyType, maxServers := range instancePlacementGroupMaxServers {
+		placementGroup := &instance.PlacementGroup{ID: "pg", Name: "pg", PolicyType: policyType}
+
+		assert.Empty(t, placementGroupServersDiagnostics(placementGroup, newServers(maxServers)))
+
+		diags := placementGroupServersDiagnostics(placementGroup, newServers(maxServers+1))
+		assert.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+	}
+
+	// Unknown policy types have no known limit.
+	assert.False(t, placementGroupHasTooManyServers("unknown", 100))
+}
+
+func TestFilterPlacementGroupUnrespectedServers(t *testing.T) {
+	servers := []*instance.PlacementGroupServer{
+		{ID: "respected", PolicyRespected: true},
+		{ID: "unrespected", PolicyRespected: false},
+	}
+
+	unrespectedServers := filterPlacementGroupUnrespectedServers(servers)
+	assert.Len(t, unrespectedServers, 1)
+	assert.Equal(t, "unrespected", unrespectedServers[0].ID)
+}
+
+func TestGetInstanceServerTypeMonthlyPrice(t *testing.T) {
+	meta := &Meta{instanceServerTypes: &sync.Map{}}
+	meta.instanceServerTypes.Store(scw.ZoneFrPar1, map[string]*instance.ServerType{
+		"DEV1-S": {MonthlyPrice: 7.99, HourlyPrice: 0.01},
+		"GP1-XS": {HourlyPrice: 0.1},
+	})
+
+	price, err := getInstanceServerTypeMonthlyPrice(context.Background(), meta, scw.ZoneFrPar1, "DEV1-S")
+	assert.NoError(t, err)
+	assert.Equal(t, 7.99, price)
+
+	// Kubernetes node types are matched ignoring case and hyphens, the monthly price is estimated from the hourly price.
+	price, err = getInstanceServerTypeMonthlyPrice(context.Background(), meta, scw.ZoneFrPar1, "gp1_xs")
+	assert.NoError(t, err)
+	assert.Equal(t, 73.0, price)
+
+	_, err = getInstanceServerTypeMonthlyPrice(context.Background(), meta, scw.ZoneFrPar1, "unknown")
+	assert.Error(t, err)
+}
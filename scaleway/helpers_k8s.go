@@ -7,9 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+	"gopkg.in/yaml.v2"
 )
 
 type KubeconfigStruct struct {
@@ -37,12 +41,39 @@ type KubeconfigStruct struct {
 	} `yaml:"users"`
 }
 
+// k8sKubeconfigExec is the exec credential plugin of a kubeconfig user
+type k8sKubeconfigExec struct {
+	APIVersion string                 `yaml:"apiVersion"`
+	Command    string                 `yaml:"command"`
+	Args       []string               `yaml:"args,omitempty"`
+	Env        []k8sKubeconfigExecEnv `yaml:"env,omitempty"`
+}
+
+type k8sKubeconfigExecEnv struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value"`
+}
+
 const (
 	defaultK8SClusterTimeout             = 10 * time.Minute
 	defaultK8SPoolTimeout                = 10 * time.Minute
 	K8SClusterWaitForPoolRequiredTimeout = 10 * time.Minute
 	K8SClusterWaitForDeletedTimeout      = 10 * time.Minute
 	K8SPoolWaitForReadyTimeout           = 10 * time.Minute
+	K8SNodeWaitForReadyTimeout           = 10 * time.Minute
+	K8SNodeWaitForActionTimeout          = 5 * time.Minute
+
+	k8sPoolReplaceStrategyRecreate  = "recreate"
+	k8sPoolReplaceStrategyBlueGreen = "blue_green"
+	k8sPoolReplacementNameSuffix    = "-replacement"
+
+	k8sNodeActionReboot  = "reboot"
+	k8sNodeActionReplace = "replace"
+
+	k8sExecCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+	// k8sMaxPoolMinorVersionSkew is the number of minor versions pools may be behind the control plane.
+	k8sMaxPoolMinorVersionSkew = 1
 )
 
 func k8sAPIWithRegion(d *schema.ResourceData, m interface{}) (*k8s.API, scw.Region, error) {
@@ -76,30 +107,230 @@ func k8sGetMinorVersionFromFull(version string) (string, error) {
 	return versionSplit[0] + "." + versionSplit[1], nil
 }
 
+// k8sParseMinorVersion returns the major and minor numbers of a x.y or x.y.z version
+func k8sParseMinorVersion(version string) (int, int, error) {
+	versionSplit := strings.Split(version, ".")
+	if len(versionSplit) != 2 && len(versionSplit) != 3 {
+		return 0, 0, fmt.Errorf("version should be like x.y or x.y.z not %s", version)
+	}
+
+	major, err := strconv.Atoi(versionSplit[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("version should be like x.y or x.y.z not %s", version)
+	}
+	minor, err := strconv.Atoi(versionSplit[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("version should be like x.y or x.y.z not %s", version)
+	}
+
+	return major, minor, nil
+}
+
+// k8sMinorVersionSkew returns the number of minor versions poolVersion is behind clusterVersion
+func k8sMinorVersionSkew(clusterVersion string, poolVersion string) (int, error) {
+	clusterMajor, clusterMinor, err := k8sParseMinorVersion(clusterVersion)
+	if err != nil {
+		return 0, err
+	}
+	poolMajor, poolMinor, err := k8sParseMinorVersion(poolVersion)
+	if err != nil {
+		return 0, err
+	}
+	if clusterMajor != poolMajor {
+		return 0, fmt.Errorf("cannot compare versions %s and %s with different major versions", clusterVersion, poolVersion)
+	}
+
+	return clusterMinor - poolMinor, nil
+}
+
+// k8sCompareVersions compares two full versions (x.y.z) and returns -1, 0 or 1
+// when version1 is older than, the same as or newer than version2.
+func k8sCompareVersions(version1 string, version2 string) (int, error) {
+	split1 := strings.Split(version1, ".")
+	split2 := strings.Split(version2, ".")
+	if len(split1) != 3 {
+		return 0, fmt.Errorf("version should be like x.y.z not %s", version1)
+	}
+	if len(split2) != 3 {
+		return 0, fmt.Errorf("version should be like x.y.z not %s", version2)
+	}
+
+	for i := range split1 {
+		number1, err := strconv.Atoi(split1[i])
+		if err != nil {
+			return 0, fmt.Errorf("version should be like x.y.z not %s", version1)
+		}
+		number2, err := strconv.Atoi(split2[i])
+		if err != nil {
+			return 0, fmt.Errorf("version should be like x.y.z not %s", version2)
+		}
+		if number1 < number2 {
+			return -1, nil
+		}
+		if number1 > number2 {
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
 // k8sGetLatestVersionFromMinor returns the latest full version (x.y.z) for a given minor version (x.y)
 func k8sGetLatestVersionFromMinor(ctx context.Context, k8sAPI *k8s.API, region scw.Region, version string) (string, error) {
-	versionSplit := strings.Split(version, ".")
-	if len(versionSplit) != 2 {
+	if len(strings.Split(version, ".")) != 2 {
 		return "", fmt.Errorf("minor version should be like x.y not %s", version)
 	}
 
+	v, err := k8sGetVersion(ctx, k8sAPI, region, version)
+	if err != nil {
+		return "", err
+	}
+
+	return v.Name, nil
+}
+
+// k8sGetVersion returns the available version matching a full version (x.y.z),
+// or the latest patch version of a minor version (x.y).
+func k8sGetVersion(ctx context.Context, k8sAPI *k8s.API, region scw.Region, version string) (*k8s.Version, error) {
 	versionsResp, err := k8sAPI.ListVersions(&k8s.ListVersionsRequest{
 		Region: region,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return k8sFindVersion(versionsResp.Versions, version)
+}
+
+// k8sFindVersion returns the version matching a full version (x.y.z), or the latest patch version of a minor version (x.y).
+func k8sFindVersion(versions []*k8s.Version, version string) (*k8s.Version, error) {
+	major, minor, err := k8sParseMinorVersion(version)
+	if err != nil {
+		return nil, err
 	}
+	isFullVersion := len(strings.Split(version, ".")) == 3
+
+	var latest *k8s.Version
+	latestPatch := -1
+	for _, v := range versions {
+		if isFullVersion {
+			if v.Name == version {
+				return v, nil
+			}
+			continue
+		}
 
-	for _, v := range versionsResp.Versions {
 		vSplit := strings.Split(v.Name, ".")
 		if len(vSplit) != 3 {
-			return "", fmt.Errorf("upstream version %s is not correctly formatted", v.Name) // should never happen
+			return nil, fmt.Errorf("upstream version %s is not correctly formatted", v.Name) // should never happen
+		}
+		vMajor, vMinor, err := k8sParseMinorVersion(v.Name)
+		if err != nil {
+			return nil, err
+		}
+		patch, err := strconv.Atoi(vSplit[2])
+		if err != nil {
+			return nil, fmt.Errorf("upstream version %s is not correctly formatted", v.Name) // should never happen
 		}
-		if versionSplit[0] == vSplit[0] && versionSplit[1] == vSplit[1] {
-			return v.Name, nil
+		if vMajor == major && vMinor == minor && patch > latestPatch {
+			latest = v
+			latestPatch = patch
 		}
 	}
-	return "", fmt.Errorf("no available upstream version found for %s", version)
+
+	if latest == nil {
+		return nil, fmt.Errorf("no available upstream version found for %s", version)
+	}
+	return latest, nil
+}
+
+// k8sUnsupportedValues returns the values that are not in the available ones
+func k8sUnsupportedValues(available []string, values []string) []string {
+	availableSet := make(map[string]struct{}, len(available))
+	for _, value := range available {
+		availableSet[value] = struct{}{}
+	}
+
+	unsupported := []string(nil)
+	for _, value := range values {
+		if _, ok := availableSet[value]; !ok {
+			unsupported = append(unsupported, value)
+		}
+	}
+	return unsupported
+}
+
+// k8sCheckVersionSupports returns an error when some values of a capability are not supported by a version
+func k8sCheckVersionSupports(version *k8s.Version, capability string, available []string, values []string) error {
+	unsupported := k8sUnsupportedValues(available, values)
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s %s not supported by version %s, available values are: %s", capability, strings.Join(unsupported, ", "), version.Name, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+func flattenK8SVersionCNIs(version *k8s.Version) []string {
+	cnis := []string(nil)
+	for _, cni := range version.AvailableCnis {
+		cnis = append(cnis, cni.String())
+	}
+	return cnis
+}
+
+func flattenK8SVersionIngresses(version *k8s.Version) []string {
+	ingresses := []string(nil)
+	for _, ingress := range version.AvailableIngresses {
+		ingresses = append(ingresses, ingress.String())
+	}
+	return ingresses
+}
+
+func flattenK8SVersionContainerRuntimes(version *k8s.Version) []string {
+	runtimes := []string(nil)
+	for _, runtime := range version.AvailableContainerRuntimes {
+		runtimes = append(runtimes, runtime.String())
+	}
+	return runtimes
+}
+
+// k8sExecKubeconfig renders the kubeconfig with its users authenticating through an exec credential plugin instead of the admin token
+func k8sExecKubeconfig(kubeconfig *k8s.Kubeconfig, exec *k8sKubeconfigExec) (string, error) {
+	type user struct {
+		Exec *k8sKubeconfigExec `yaml:"exec"`
+	}
+	type userWithName struct {
+		Name string `yaml:"name"`
+		User user   `yaml:"user"`
+	}
+
+	users := make([]userWithName, 0, len(kubeconfig.Users))
+	for _, u := range kubeconfig.Users {
+		users = append(users, userWithName{
+			Name: u.Name,
+			User: user{Exec: exec},
+		})
+	}
+
+	config, err := yaml.Marshal(struct {
+		APIVersion     string                           `yaml:"apiVersion"`
+		Kind           string                           `yaml:"kind"`
+		CurrentContext string                           `yaml:"current-context"`
+		Clusters       []*k8s.KubeconfigClusterWithName `yaml:"clusters"`
+		Contexts       []*k8s.KubeconfigContextWithName `yaml:"contexts"`
+		Users          []userWithName                   `yaml:"users"`
+	}{
+		APIVersion:     kubeconfig.APIVersion,
+		Kind:           kubeconfig.Kind,
+		CurrentContext: kubeconfig.CurrentContext,
+		Clusters:       kubeconfig.Clusters,
+		Contexts:       kubeconfig.Contexts,
+		Users:          users,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(config), nil
 }
 
 func waitK8SCluster(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, desiredStates ...k8s.ClusterStatus) error {
@@ -154,6 +385,336 @@ func waitK8SPoolReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, p
 	return fmt.Errorf("pool %s has state %s, wants %s", poolID, pool.Status, k8s.PoolStatusReady)
 }
 
+func waitK8SPoolDeleted(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string) error {
+	pool, err := k8sAPI.WaitForPool(&k8s.WaitForPoolRequest{
+		PoolID:  poolID,
+		Region:  region,
+		Timeout: scw.TimeDurationPtr(K8SPoolWaitForReadyTimeout),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return err
+	}
+
+	return fmt.Errorf("pool %s has state %s, wants %s", poolID, pool.Status, k8s.PoolStatusDeleted)
+}
+
+// deleteK8SPool deletes a pool and waits for its deletion. A pool already deleted is ignored.
+func deleteK8SPool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string) error {
+	_, err := k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+		Region: region,
+		PoolID: poolID,
+	}, scw.WithContext(ctx))
+	if err == nil {
+		err = waitK8SPoolDeleted(ctx, k8sAPI, region, poolID)
+	}
+	if err != nil && !is404Error(err) {
+		return err
+	}
+	return nil
+}
+
+// k8sReplacePool creates a pool from req next to the pool to replace and waits for all its nodes to be ready.
+// The replaced pool is deleted once the grace period has elapsed, leaving time for the workloads to be rescheduled.
+// The returned pool is nil when the new pool could not be created or got rolled back. When the returned pool is not nil
+// but diagnostics hold an error, the new pool replaces the pool but the replaced pool could not be deleted.
+func k8sReplacePool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string, req *k8s.CreatePoolRequest, gracePeriod time.Duration) (*k8s.Pool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	progress := func(format string, args ...interface{}) {
+		l.Infof(format, args...)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	pool, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	progress("pool replacement: created pool %s (%s) with node type %s to replace pool %s", pool.Name, pool.ID, pool.NodeType, poolID)
+
+	err = waitK8SPoolReady(ctx, k8sAPI, region, pool.ID)
+	if err != nil {
+		detail := fmt.Sprintf("pool %s was left untouched and pool %s was deleted", poolID, pool.ID)
+		_, deleteErr := k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+			Region: region,
+			PoolID: pool.ID,
+		}, scw.WithContext(ctx))
+		if deleteErr != nil && !is404Error(deleteErr) {
+			detail = fmt.Sprintf("pool %s was left untouched but pool %s could not be deleted: %s", poolID, pool.ID, deleteErr)
+		}
+		return nil, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("pool replacement: pool %s did not become ready: %s", pool.ID, err),
+			Detail:   detail,
+		})
+	}
+	progress("pool replacement: pool %s (%s) is ready", pool.Name, pool.ID)
+
+	if gracePeriod > 0 {
+		progress("pool replacement: waiting %s before deleting pool %s", gracePeriod, poolID)
+		select {
+		case <-time.After(gracePeriod):
+		case <-ctx.Done():
+			return pool, append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("pool replacement: interrupted before deleting pool %s: %s", poolID, ctx.Err()),
+				Detail:   fmt.Sprintf("pool %s is ready and replaces pool %s, whose deletion is retried by the next apply", pool.ID, poolID),
+			})
+		}
+	}
+
+	err = deleteK8SPool(ctx, k8sAPI, region, poolID)
+	if err != nil {
+		return pool, append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("pool replacement: cannot delete pool %s: %s", poolID, err),
+			Detail:   fmt.Sprintf("pool %s is ready and replaces pool %s, whose deletion is retried by the next apply", pool.ID, poolID),
+		})
+	}
+	progress("pool replacement: deleted pool %s", poolID)
+
+	return pool, diags
+}
+
+// k8sPoolReplacementName returns the name of the pool replacing a pool currently named currentName.
+// Pool names are unique within a cluster, so a pool keeping its name alternates between name and name suffixed.
+func k8sPoolReplacementName(name string, currentName string) string {
+	if name != currentName {
+		return name
+	}
+	return name + k8sPoolReplacementNameSuffix
+}
+
+// flattenK8SPoolName returns the name of the pool as configured, ignoring the suffix added by a blue_green replacement.
+func flattenK8SPoolName(name string, poolName string) string {
+	if poolName == name+k8sPoolReplacementNameSuffix {
+		return name
+	}
+	return poolName
+}
+
+// k8sUpgradePools upgrades the pools of a cluster from its previous version to the given version one after the other.
+// Pools in another version than the previous version of the cluster, or named in excludedPoolNames, are upgraded on
+// their own and left untouched.
+// Each pool is upgraded following its upgrade policy, and the next pool is upgraded once it is ready.
+func k8sUpgradePools(ctx context.Context, k8sAPI *k8s.API, region scw.Region, clusterID string, previousVersion string, version string, excludedPoolNames []string) error {
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools.Pools {
+		if pool.Version != previousVersion {
+			l.Debugf("pool %s is in version %s instead of %s, it is not upgraded with its cluster", pool.ID, pool.Version, previousVersion)
+			continue
+		}
+		if k8sPoolIsExcluded(pool.Name, excludedPoolNames) {
+			l.Debugf("pool %s is excluded, it is not upgraded with its cluster", pool.ID)
+			continue
+		}
+
+		l.Debugf("upgrading pool %s from version %s to %s", pool.ID, pool.Version, version)
+		err = k8sUpgradePool(ctx, k8sAPI, region, pool.ID, version)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// k8sPoolIsExcluded returns whether the pool named poolName in the API is one of the excluded pools.
+// The API name of a pool may be suffixed by its zone and by k8sPoolReplacementNameSuffix,
+// see k8sSplitPoolCreateRequest and k8sPoolReplacementName.
+func k8sPoolIsExcluded(poolName string, excludedPoolNames []string) bool {
+	for _, name := range excludedPoolNames {
+		if !strings.HasPrefix(poolName, name) {
+			continue
+		}
+		suffix := strings.TrimPrefix(poolName, name)
+		suffix = strings.TrimPrefix(suffix, k8sPoolReplacementNameSuffix)
+		suffix = strings.TrimSuffix(suffix, k8sPoolReplacementNameSuffix)
+		if suffix == "" || (strings.HasPrefix(suffix, "-") && validation.IsZone(strings.TrimPrefix(suffix, "-"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// k8sUpgradePool upgrades a pool to the given version following its upgrade policy and waits for it to be ready.
+func k8sUpgradePool(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string, version string) error {
+	_, err := k8sAPI.UpgradePool(&k8s.UpgradePoolRequest{
+		Region:  region,
+		PoolID:  poolID,
+		Version: version,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	return waitK8SPoolReady(ctx, k8sAPI, region, poolID)
+}
+
+// customizeDiffK8SClusterVersionSkew refuses version changes that would put pools more than
+// k8sMaxPoolMinorVersionSkew minor versions behind the control plane when pools are not upgraded.
+func customizeDiffK8SClusterVersionSkew(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("version") || !diff.NewValueKnown("version") {
+		return nil
+	}
+
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, diff.Id())
+	if err != nil {
+		return err
+	}
+
+	pools, err := k8sAPI.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	oldVersion, version := diff.GetChange("version")
+	for _, pool := range pools.Pools {
+		// With upgrade_pools, the pools following the cluster are upgraded with it.
+		if diff.Get("upgrade_pools").(bool) && (pool.Version == oldVersion.(string) || strings.HasPrefix(pool.Version, oldVersion.(string)+".")) &&
+			!k8sPoolIsExcluded(pool.Name, expandStrings(diff.Get("upgrade_pools_exclude"))) {
+			continue
+		}
+
+		skew, err := k8sMinorVersionSkew(version.(string), pool.Version)
+		if err != nil {
+			return err
+		}
+		if skew > k8sMaxPoolMinorVersionSkew {
+			return fmt.Errorf("pool %s is in version %s: upgrading the cluster to %s would put it more than %d minor version behind, upgrade the pool first", pool.Name, pool.Version, version, k8sMaxPoolMinorVersionSkew)
+		}
+	}
+
+	return nil
+}
+
+func waitK8SNodeReady(ctx context.Context, k8sAPI *k8s.API, region scw.Region, nodeID string) error {
+	node, err := k8sAPI.WaitForNode(&k8s.WaitForNodeRequest{
+		NodeID:  nodeID,
+		Region:  region,
+		Timeout: scw.TimeDurationPtr(K8SNodeWaitForReadyTimeout),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if node.Status == k8s.NodeStatusReady {
+		return nil
+	}
+	return fmt.Errorf("node %s has state %s, wants %s", nodeID, node.Status, k8s.NodeStatusReady)
+}
+
+// waitK8SNodeActionStarted waits for a node to leave the ready status once an action is applied on it.
+// A node that does not exist anymore, e.g. a replaced one, has started its action too.
+func waitK8SNodeActionStarted(ctx context.Context, k8sAPI *k8s.API, region scw.Region, nodeID string) error {
+	return resource.RetryContext(ctx, K8SNodeWaitForActionTimeout, func() *resource.RetryError {
+		node, err := k8sAPI.GetNode(&k8s.GetNodeRequest{
+			Region: region,
+			NodeID: nodeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if is404Error(err) {
+				return nil
+			}
+			return resource.NonRetryableError(err)
+		}
+
+		if node.Status == k8s.NodeStatusReady {
+			return resource.RetryableError(fmt.Errorf("node %s is still %s", nodeID, node.Status))
+		}
+		return nil
+	})
+}
+
+// filterK8SNodes returns the nodes matching all the given names and the given status.
+// Empty names or status match every node.
+func filterK8SNodes(nodes []*k8s.Node, names []string, status string) ([]*k8s.Node, error) {
+	namesSet := make(map[string]bool, len(names))
+	for _, name := range names {
+		namesSet[name] = false
+	}
+
+	filtered := []*k8s.Node(nil)
+	for _, node := range nodes {
+		if _, ok := namesSet[node.Name]; len(names) > 0 && !ok {
+			continue
+		}
+		namesSet[node.Name] = true
+		if status != "" && node.Status.String() != status {
+			continue
+		}
+		filtered = append(filtered, node)
+	}
+
+	for _, name := range names {
+		if !namesSet[name] {
+			return nil, fmt.Errorf("node %s not found in the pool", name)
+		}
+	}
+
+	return filtered, nil
+}
+
+// k8sApplyNodeAction reboots or replaces the nodes one at a time.
+// Each node must leave the ready status and the pool must be ready again before the action is applied on the next node.
+func k8sApplyNodeAction(ctx context.Context, k8sAPI *k8s.API, region scw.Region, poolID string, action string, nodes []*k8s.Node) error {
+	for _, node := range nodes {
+		l.Infof("%s node %s (%s) of pool %s", action, node.Name, node.ID, poolID)
+
+		var err error
+		switch action {
+		case k8sNodeActionReboot:
+			_, err = k8sAPI.RebootNode(&k8s.RebootNodeRequest{
+				Region: region,
+				NodeID: node.ID,
+			}, scw.WithContext(ctx))
+		case k8sNodeActionReplace:
+			_, err = k8sAPI.ReplaceNode(&k8s.ReplaceNodeRequest{
+				Region: region,
+				NodeID: node.ID,
+			}, scw.WithContext(ctx))
+		default:
+			err = fmt.Errorf("unknown node action %s", action)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot %s node %s: %s", action, node.Name, err)
+		}
+
+		// The node is still ready right after the action, wait for the action to start before waiting for the node.
+		err = waitK8SNodeActionStarted(ctx, k8sAPI, region, node.ID)
+		if err != nil {
+			return err
+		}
+
+		// A replaced node may not exist anymore, the pool readiness tells when it is back.
+		err = waitK8SNodeReady(ctx, k8sAPI, region, node.ID)
+		if err != nil && !is404Error(err) {
+			return err
+		}
+
+		err = waitK8SPoolReady(ctx, k8sAPI, region, poolID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // convert a list of nodes to a list of map
 func convertNodes(res *k8s.ListNodesResponse) []map[string]interface{} {
 	var result []map[string]interface{}
@@ -185,7 +746,56 @@ func getNodes(ctx context.Context, k8sAPI *k8s.API, pool *k8s.Pool) ([]map[strin
 		return nil, err
 	}
 
-	return convertNodes(nodes), nil
+	result := convertNodes(nodes)
+	for _, node := range result {
+		node["zone"] = pool.Zone.String()
+	}
+
+	return result, nil
+}
+
+// k8sSplitPoolSize splits size evenly between count pools, the first pools getting the remainder.
+func k8sSplitPoolSize(size uint32, count int) []uint32 {
+	sizes := make([]uint32, count)
+	for i := range sizes {
+		sizes[i] = size / uint32(count)
+		if uint32(i) < size%uint32(count) {
+			sizes[i]++
+		}
+	}
+
+	return sizes
+}
+
+// k8sSplitPoolCreateRequest returns one request per zone sharing the settings of req and its sizes evenly.
+// Pool names being unique within a cluster, each pool name is suffixed by its zone.
+func k8sSplitPoolCreateRequest(req *k8s.CreatePoolRequest, zones []string) []*k8s.CreatePoolRequest {
+	sizes := k8sSplitPoolSize(req.Size, len(zones))
+	minSizes := []uint32(nil)
+	if req.MinSize != nil {
+		minSizes = k8sSplitPoolSize(*req.MinSize, len(zones))
+	}
+	maxSizes := []uint32(nil)
+	if req.MaxSize != nil {
+		maxSizes = k8sSplitPoolSize(*req.MaxSize, len(zones))
+	}
+
+	reqs := []*k8s.CreatePoolRequest(nil)
+	for i, zone := range zones {
+		zoneReq := *req
+		zoneReq.Name = req.Name + "-" + zone
+		zoneReq.Zone = scw.Zone(zone)
+		zoneReq.Size = sizes[i]
+		if minSizes != nil {
+			zoneReq.MinSize = scw.Uint32Ptr(minSizes[i])
+		}
+		if maxSizes != nil {
+			zoneReq.MaxSize = scw.Uint32Ptr(maxSizes[i])
+		}
+		reqs = append(reqs, &zoneReq)
+	}
+
+	return reqs
 }
 
 func clusterAutoscalerConfigFlatten(cluster *k8s.Cluster) []map[string]interface{} {
@@ -262,3 +872,296 @@ func flattenKubeletArgs(args map[string]string) map[string]interface{} {
 
 	return kubeletArgs
 }
+
+// getK8SPoolEstimatedMonthlyCost returns the estimated monthly cost in Euro of size nodes of the given node type
+// spread evenly over the given zones. Nodes are instances: the price comes from the instance server types.
+func getK8SPoolEstimatedMonthlyCost(ctx context.Context, meta interface{}, zones []scw.Zone, nodeType string, size int) (float64, error) {
+	sizes := k8sSplitPoolSize(uint32(size), len(zones))
+
+	cost := float64(0)
+	for i, zone := range zones {
+		price, err := getInstanceServerTypeMonthlyPrice(ctx, meta, zone, nodeType)
+		if err != nil {
+			return 0, err
+		}
+		cost += price * float64(sizes[i])
+	}
+	return flattenPrice(cost), nil
+}
+
+// customizeDiffK8SPoolEstimatedMonthlyCost computes the estimated_monthly_cost of a pool at plan time.
+// The cost of a pool whose zone is chosen by the API is only known once the pool is created.
+func customizeDiffK8SPoolEstimatedMonthlyCost(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" && !diff.HasChange("node_type") && !diff.HasChange("size") {
+		return nil
+	}
+	if !diff.NewValueKnown("node_type") || !diff.NewValueKnown("size") || !diff.NewValueKnown("zone") || !diff.NewValueKnown("zones") {
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	zones := []scw.Zone(nil)
+	for _, zone := range expandStrings(diff.Get("zones")) {
+		zones = append(zones, scw.Zone(zone))
+	}
+	if zone := diff.Get("zone").(string); len(zones) == 0 && zone != "" {
+		zones = []scw.Zone{scw.Zone(zone)}
+	}
+	if len(zones) == 0 {
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	cost, err := getK8SPoolEstimatedMonthlyCost(ctx, meta, zones, diff.Get("node_type").(string), diff.Get("size").(int))
+	if err != nil {
+		l.Warningf("cannot estimate the monthly cost of the pool: %s", err)
+		return diff.SetNewComputed("estimated_monthly_cost")
+	}
+
+	return diff.SetNew("estimated_monthly_cost", cost)
+}
+
+// customizeDiffK8SPoolPreviousPool plans an update of the pools whose replaced pool could not be deleted,
+// so that its deletion is retried by the apply.
+func customizeDiffK8SPoolPreviousPool(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Get("previous_pool_id").(string) == "" {
+		return nil
+	}
+	return diff.SetNew("previous_pool_id", "")
+}
+
+// customizeDiffK8SPoolZones checks that each zone of a pool spread over zones gets at least one node.
+func customizeDiffK8SPoolZones(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("zones") || !diff.NewValueKnown("size") {
+		return nil
+	}
+
+	zones := expandStrings(diff.Get("zones"))
+	if len(zones) == 0 {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for _, zone := range zones {
+		if seen[zone] {
+			return fmt.Errorf("zone %s is listed more than once in zones", zone)
+		}
+		seen[zone] = true
+	}
+
+	if size := diff.Get("size").(int); size < len(zones) {
+		return fmt.Errorf("size %d is lower than the number of zones %d, each zone would not get a node", size, len(zones))
+	}
+
+	return nil
+}
+
+// customizeDiffK8SPoolMissingPools recreates a pool spread over zones when some of its pools were deleted
+// outside of terraform, as they are dropped from pool_ids when the pool is read.
+func customizeDiffK8SPoolMissingPools(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || diff.HasChange("zones") {
+		return nil
+	}
+
+	zones := expandStrings(diff.Get("zones"))
+	if len(zones) == 0 || len(expandStrings(diff.Get("pool_ids"))) >= len(zones) {
+		return nil
+	}
+
+	err := diff.SetNewComputed("pool_ids")
+	if err != nil {
+		return err
+	}
+	return diff.ForceNew("pool_ids")
+}
+
+// customizeDiffK8SPoolReplaceStrategy forces a new pool when its name or node type changes,
+// unless the pool is replaced in place with the blue_green strategy.
+func customizeDiffK8SPoolReplaceStrategy(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	// Pools spread over zones are always recreated.
+	_, spread := diff.GetOk("zones")
+	if diff.Id() == "" || (diff.Get("replace_strategy").(string) == k8sPoolReplaceStrategyBlueGreen && !spread) {
+		return nil
+	}
+
+	for _, key := range []string{"name", "node_type"} {
+		if diff.HasChange(key) {
+			err := diff.ForceNew(key)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffK8SClusterVersionCapabilities checks at plan time that the cni, the ingress, the feature gates
+// and the admission plugins of the cluster are supported by its version.
+func customizeDiffK8SClusterVersionCapabilities(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// The versions are listed only when one of these fields changes.
+	if !diff.HasChange("version") && !diff.HasChange("cni") && !diff.HasChange("ingress") && !diff.HasChange("feature_gates") && !diff.HasChange("admission_plugins") {
+		return nil
+	}
+	if !diff.NewValueKnown("version") {
+		return nil
+	}
+
+	region, err := extractRegionFromDiff(diff, meta.(*Meta))
+	if err != nil {
+		return err
+	}
+
+	version, err := k8sGetVersion(ctx, k8s.NewAPI(meta.(*Meta).scwClient), region, diff.Get("version").(string))
+	if err != nil {
+		if !diff.HasChange("version") {
+			// The version of an existing cluster may not be available anymore.
+			l.Warningf("cannot check the capabilities of the cluster version: %s", err)
+			return nil
+		}
+		return err
+	}
+
+	if diff.NewValueKnown("cni") {
+		err = k8sCheckVersionSupports(version, "cni", flattenK8SVersionCNIs(version), []string{diff.Get("cni").(string)})
+		if err != nil {
+			return err
+		}
+	}
+
+	if diff.NewValueKnown("ingress") && diff.Get("ingress").(string) != "" {
+		err = k8sCheckVersionSupports(version, "ingress", flattenK8SVersionIngresses(version), []string{diff.Get("ingress").(string)})
+		if err != nil {
+			return err
+		}
+	}
+
+	if diff.NewValueKnown("feature_gates") {
+		err = k8sCheckVersionSupports(version, "feature gates", version.AvailableFeatureGates, expandStrings(diff.Get("feature_gates")))
+		if err != nil {
+			return err
+		}
+	}
+
+	if diff.NewValueKnown("admission_plugins") {
+		err = k8sCheckVersionSupports(version, "admission plugins", version.AvailableAdmissionPlugins, expandStrings(diff.Get("admission_plugins")))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// customizeDiffK8SPoolVersionCapabilities checks at plan time that the container runtime of the pool
+// is supported by the version of its cluster.
+func customizeDiffK8SPoolVersionCapabilities(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.HasChange("container_runtime") || !diff.NewValueKnown("container_runtime") || !diff.NewValueKnown("cluster_id") {
+		return nil
+	}
+	// The default container runtime is left to the API.
+	if diff.Get("container_runtime").(string) == k8s.RuntimeContainerd.String() {
+		return nil
+	}
+
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, diff.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return err
+	}
+
+	version, err := k8sGetVersion(ctx, k8sAPI, region, cluster.Version)
+	if err != nil {
+		l.Warningf("cannot check the capabilities of the cluster version: %s", err)
+		return nil
+	}
+
+	return k8sCheckVersionSupports(version, "container runtime", flattenK8SVersionContainerRuntimes(version), []string{diff.Get("container_runtime").(string)})
+}
+
+// customizeDiffK8SPoolVersion checks at plan time that an existing pool is not downgraded
+// and is not left too far behind the current version of its cluster.
+// The checks against the planned version of the cluster are done at apply time by k8sCheckPoolVersion,
+// as the cluster may be upgraded by the same apply.
+func customizeDiffK8SPoolVersion(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("version") || !diff.NewValueKnown("version") || !diff.NewValueKnown("cluster_id") {
+		return nil
+	}
+	version := diff.Get("version").(string)
+	if version == "" {
+		return nil
+	}
+
+	oldVersion, _ := diff.GetChange("version")
+	cmp, err := k8sCompareVersions(version, oldVersion.(string))
+	if err != nil {
+		return err
+	}
+	if cmp < 0 {
+		return fmt.Errorf("pool cannot be downgraded from version %s to %s, when its cluster sets upgrade_pools the pool is upgraded with it unless listed in upgrade_pools_exclude", oldVersion, version)
+	}
+
+	k8sAPI, region, clusterID, err := k8sAPIWithRegionAndID(meta, diff.Get("cluster_id").(string))
+	if err != nil {
+		return err
+	}
+
+	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return err
+	}
+
+	skew, err := k8sMinorVersionSkew(cluster.Version, version)
+	if err != nil {
+		return err
+	}
+	if skew > k8sMaxPoolMinorVersionSkew {
+		return fmt.Errorf("pool version %s would be more than %d minor version behind the version %s of its cluster", version, k8sMaxPoolMinorVersionSkew, cluster.Version)
+	}
+
+	return nil
+}
+
+// k8sCheckPoolVersion checks that a pool can be in the given version with the version of its cluster:
+// new pools are created in the version of their cluster, and existing pools can only be upgraded
+// up to the version of their cluster.
+func k8sCheckPoolVersion(cluster *k8s.Cluster, version string, isCreation bool) error {
+	if isCreation {
+		if version != cluster.Version {
+			return fmt.Errorf("pools are created in the version of their cluster %s, not %s", cluster.Version, version)
+		}
+		return nil
+	}
+
+	cmp, err := k8sCompareVersions(version, cluster.Version)
+	if err != nil {
+		return err
+	}
+	if cmp > 0 {
+		return fmt.Errorf("pool cannot be upgraded to version %s which is newer than the version %s of its cluster, upgrade the cluster first", version, cluster.Version)
+	}
+
+	skew, err := k8sMinorVersionSkew(cluster.Version, version)
+	if err != nil {
+		return err
+	}
+	if skew > k8sMaxPoolMinorVersionSkew {
+		return fmt.Errorf("pool version %s would be more than %d minor version behind the version %s of its cluster", version, k8sMaxPoolMinorVersionSkew, cluster.Version)
+	}
+
+	return nil
+}
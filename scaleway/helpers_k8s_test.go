@@ -0,0 +1,463 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestK8SMinorVersionSkew(t *testing.T) {
+	tests := []struct {
+		name           string
+		clusterVersion string
+		poolVersion    string
+		expected       int
+		expectedErr    bool
+	}{
+		{
+			name:           "same version",
+			clusterVersion: "1.20.5",
+			poolVersion:    "1.20.5",
+			expected:       0,
+		},
+		{
+			name:           "patch upgrade",
+			clusterVersion: "1.20.6",
+			poolVersion:    "1.20.5",
+			expected:       0,
+		},
+		{
+			name:           "minor version",
+			clusterVersion: "1.21",
+			poolVersion:    "1.20.5",
+			expected:       1,
+		},
+		{
+			name:           "two minor versions",
+			clusterVersion: "1.21.0",
+			poolVersion:    "1.19.9",
+			expected:       2,
+		},
+		{
+			name:           "different major versions",
+			clusterVersion: "2.0.0",
+			poolVersion:    "1.20.5",
+			expectedErr:    true,
+		},
+		{
+			name:           "invalid version",
+			clusterVersion: "latest",
+			poolVersion:    "1.20.5",
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			skew, err := k8sMinorVersionSkew(test.clusterVersion, test.poolVersion)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, skew)
+		})
+	}
+}
+
+func TestK8SCompareVersions(t *testing.T) {
+	tests := []struct {
+		name        string
+		version1    string
+		version2    string
+		expected    int
+		expectedErr bool
+	}{
+		{
+			name:     "same version",
+			version1: "1.20.5",
+			version2: "1.20.5",
+			expected: 0,
+		},
+		{
+			name:     "older patch",
+			version1: "1.20.5",
+			version2: "1.20.10",
+			expected: -1,
+		},
+		{
+			name:     "newer minor",
+			version1: "1.21.0",
+			version2: "1.20.10",
+			expected: 1,
+		},
+		{
+			name:        "minor version",
+			version1:    "1.21",
+			version2:    "1.20.5",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid version",
+			version1:    "1.20.5",
+			version2:    "1.20.x",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmp, err := k8sCompareVersions(test.version1, test.version2)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, cmp)
+		})
+	}
+}
+
+func TestK8SCheckPoolVersion(t *testing.T) {
+	cluster := &k8s.Cluster{Version: "1.21.1"}
+
+	tests := []struct {
+		name        string
+		version     string
+		isCreation  bool
+		expectedErr bool
+	}{
+		{
+			name:       "creation in the cluster version",
+			version:    "1.21.1",
+			isCreation: true,
+		},
+		{
+			name:        "creation in another version",
+			version:     "1.20.5",
+			isCreation:  true,
+			expectedErr: true,
+		},
+		{
+			name:    "upgrade to the cluster version",
+			version: "1.21.1",
+		},
+		{
+			name:    "upgrade behind the cluster version",
+			version: "1.20.5",
+		},
+		{
+			name:        "upgrade newer than the cluster version",
+			version:     "1.21.2",
+			expectedErr: true,
+		},
+		{
+			name:        "upgrade too far behind the cluster version",
+			version:     "1.19.9",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := k8sCheckPoolVersion(cluster, test.version, test.isCreation)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestK8SPoolIsExcluded(t *testing.T) {
+	excludedPoolNames := []string{"canary", "web"}
+
+	assert.True(t, k8sPoolIsExcluded("canary", excludedPoolNames))
+	assert.True(t, k8sPoolIsExcluded("canary-replacement", excludedPoolNames))
+	assert.True(t, k8sPoolIsExcluded("web-fr-par-2", excludedPoolNames))
+	assert.True(t, k8sPoolIsExcluded("web-fr-par-2-replacement", excludedPoolNames))
+	assert.False(t, k8sPoolIsExcluded("default", excludedPoolNames))
+	assert.False(t, k8sPoolIsExcluded("web-api", excludedPoolNames))
+	assert.False(t, k8sPoolIsExcluded("canary", nil))
+}
+
+func TestK8SFindVersion(t *testing.T) {
+	versions := []*k8s.Version{
+		{Name: "1.20.2"},
+		{Name: "1.20.10"},
+		{Name: "1.19.8"},
+		{Name: "1.2.0"},
+	}
+
+	tests := []struct {
+		name        string
+		version     string
+		expected    string
+		expectedErr bool
+	}{
+		{
+			name:     "full version",
+			version:  "1.19.8",
+			expected: "1.19.8",
+		},
+		{
+			name:     "latest patch of a minor version",
+			version:  "1.20",
+			expected: "1.20.10",
+		},
+		{
+			name:     "minor version prefix of another one",
+			version:  "1.2",
+			expected: "1.2.0",
+		},
+		{
+			name:        "unavailable full version",
+			version:     "1.19.7",
+			expectedErr: true,
+		},
+		{
+			name:        "unavailable minor version",
+			version:     "1.18",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid version",
+			version:     "latest",
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			version, err := k8sFindVersion(versions, test.version)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, version.Name)
+		})
+	}
+}
+
+func TestK8SUnsupportedValues(t *testing.T) {
+	available := []string{"PodNodeSelector", "PodTolerationRestriction", "AlwaysPullImages"}
+
+	assert.Empty(t, k8sUnsupportedValues(available, nil))
+	assert.Empty(t, k8sUnsupportedValues(available, []string{"AlwaysPullImages", "PodNodeSelector"}))
+	assert.Equal(t, []string{"EventRateLimit"}, k8sUnsupportedValues(available, []string{"AlwaysPullImages", "EventRateLimit"}))
+	assert.Equal(t, []string{"cilium"}, k8sUnsupportedValues(nil, []string{"cilium"}))
+}
+
+func TestK8SExecKubeconfig(t *testing.T) {
+	kubeconfig := &k8s.Kubeconfig{
+		APIVersion:     "v1",
+		Kind:           "Config",
+		CurrentContext: "admin@cluster",
+		Clusters: []*k8s.KubeconfigClusterWithName{
+			{
+				Name: "cluster",
+				Cluster: k8s.KubeconfigCluster{
+					Server:                   "https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443",
+					CertificateAuthorityData: "Y2VydGlmaWNhdGU=",
+				},
+			},
+		},
+		Contexts: []*k8s.KubeconfigContextWithName{
+			{
+				Name: "admin@cluster",
+				Context: k8s.KubeconfigContext{
+					Cluster: "cluster",
+					User:    "cluster-admin",
+				},
+			},
+		},
+		Users: []*k8s.KubeconfigUserWithName{
+			{
+				Name: "cluster-admin",
+				User: k8s.KubeconfigUser{
+					Token: "secret",
+				},
+			},
+		},
+	}
+
+	config, err := k8sExecKubeconfig(kubeconfig, &k8sKubeconfigExec{
+		APIVersion: k8sExecCredentialAPIVersion,
+		Command:    "scw",
+		Args:       []string{"k8s", "exec-credential"},
+		Env: []k8sKubeconfigExecEnv{
+			{Name: "SCW_PROFILE", Value: "prod"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `apiVersion: v1
+kind: Config
+current-context: admin@cluster
+clusters:
+- name: cluster
+  cluster:
+    server: https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443
+    certificate-authority-data: Y2VydGlmaWNhdGU=
+contexts:
+- name: admin@cluster
+  context:
+    cluster: cluster
+    user: cluster-admin
+users:
+- name: cluster-admin
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: scw
+      args:
+      - k8s
+      - exec-credential
+      env:
+      - name: SCW_PROFILE
+        value: prod
+`, config)
+	assert.NotContains(t, config, "secret")
+}
+
+func TestFilterK8SNodes(t *testing.T) {
+	nodes := []*k8s.Node{
+		{Name: "node-1", Status: k8s.NodeStatusReady},
+		{Name: "node-2", Status: k8s.NodeStatusNotReady},
+		{Name: "node-3", Status: k8s.NodeStatusNotReady},
+	}
+
+	tests := []struct {
+		name        string
+		names       []string
+		status      string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			name:     "no filter",
+			expected: []string{"node-1", "node-2", "node-3"},
+		},
+		{
+			name:     "status",
+			status:   "not_ready",
+			expected: []string{"node-2", "node-3"},
+		},
+		{
+			name:     "names",
+			names:    []string{"node-3", "node-1"},
+			expected: []string{"node-1", "node-3"},
+		},
+		{
+			name:     "names and status",
+			names:    []string{"node-1", "node-2"},
+			status:   "not_ready",
+			expected: []string{"node-2"},
+		},
+		{
+			name:        "unknown name",
+			names:       []string{"node-1", "node-4"},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, err := filterK8SNodes(nodes, test.names, test.status)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			names := []string(nil)
+			for _, node := range filtered {
+				names = append(names, node.Name)
+			}
+			assert.Equal(t, test.expected, names)
+		})
+	}
+}
+
+func TestK8SSplitPoolSize(t *testing.T) {
+	tests := []struct {
+		name     string
+		size     uint32
+		count    int
+		expected []uint32
+	}{
+		{
+			name:     "single pool",
+			size:     5,
+			count:    1,
+			expected: []uint32{5},
+		},
+		{
+			name:     "even split",
+			size:     6,
+			count:    3,
+			expected: []uint32{2, 2, 2},
+		},
+		{
+			name:     "remainder",
+			size:     5,
+			count:    3,
+			expected: []uint32{2, 2, 1},
+		},
+		{
+			name:     "smaller than count",
+			size:     1,
+			count:    2,
+			expected: []uint32{1, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, k8sSplitPoolSize(test.size, test.count))
+		})
+	}
+}
+
+func TestK8SPoolReplacementName(t *testing.T) {
+	assert.Equal(t, "default-replacement", k8sPoolReplacementName("default", "default"))
+	assert.Equal(t, "default", k8sPoolReplacementName("default", "default-replacement"))
+	assert.Equal(t, "other", k8sPoolReplacementName("other", "default"))
+
+	assert.Equal(t, "default", flattenK8SPoolName("default", "default-replacement"))
+	assert.Equal(t, "default", flattenK8SPoolName("default", "default"))
+	assert.Equal(t, "other-replacement", flattenK8SPoolName("", "other-replacement"))
+}
+
+func TestK8SSplitPoolCreateRequest(t *testing.T) {
+	req := &k8s.CreatePoolRequest{
+		Name:     "default",
+		NodeType: "gp1_xs",
+		Size:     3,
+		MinSize:  scw.Uint32Ptr(1),
+		MaxSize:  scw.Uint32Ptr(7),
+		Zone:     scw.ZoneFrPar1,
+	}
+
+	reqs := k8sSplitPoolCreateRequest(req, []string{"fr-par-1", "fr-par-2"})
+	assert.Len(t, reqs, 2)
+
+	assert.Equal(t, "default-fr-par-1", reqs[0].Name)
+	assert.Equal(t, scw.ZoneFrPar1, reqs[0].Zone)
+	assert.Equal(t, uint32(2), reqs[0].Size)
+	assert.Equal(t, uint32(1), *reqs[0].MinSize)
+	assert.Equal(t, uint32(4), *reqs[0].MaxSize)
+
+	assert.Equal(t, "default-fr-par-2", reqs[1].Name)
+	assert.Equal(t, scw.ZoneFrPar2, reqs[1].Zone)
+	assert.Equal(t, uint32(1), reqs[1].Size)
+	assert.Equal(t, uint32(0), *reqs[1].MinSize)
+	assert.Equal(t, uint32(3), *reqs[1].MaxSize)
+	assert.Equal(t, "gp1_xs", reqs[1].NodeType)
+
+	// The original request is left untouched.
+	assert.Equal(t, "default", req.Name)
+	assert.Equal(t, uint32(3), req.Size)
+}
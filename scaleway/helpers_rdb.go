@@ -1,6 +1,9 @@
 package scaleway
 
 import (
+	"context"
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -10,6 +13,10 @@ import (
 
 const (
 	defaultRdbInstanceTimeout = 15 * time.Minute
+
+	// rdbDefaultACLRuleIP is the ACL rule of new instances, allowing any IP to reach them
+	rdbDefaultACLRuleIP          = "0.0.0.0/0"
+	rdbDefaultACLRuleDescription = "Allow All"
 )
 
 // rdbAPIWithRegion returns a new lb API and the region for a Create request
@@ -69,3 +76,100 @@ func expandInstanceSettings(i interface{}) []*rdb.InstanceSetting {
 
 	return res
 }
+
+// waitRdbInstance waits for the instance to leave its transient state, e.g. after a configuration change
+func waitRdbInstance(ctx context.Context, rdbAPI *rdb.API, region scw.Region, instanceID string) (*rdb.Instance, error) {
+	return rdbAPI.WaitForInstance(&rdb.WaitForInstanceRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Timeout:    scw.TimeDurationPtr(defaultRdbInstanceTimeout),
+	}, scw.WithContext(ctx))
+}
+
+// validateRdbACLRuleIP validates that the IP of an ACL rule is a CIDR in its canonical form,
+// as the API returns it, e.g. 1.2.3.4/32 or 10.0.0.0/8
+func validateRdbACLRuleIP(i interface{}, key string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", key)}
+	}
+
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a CIDR (e.g. 1.2.3.4/32), got %s", key, value)}
+	}
+	if ipNet.String() != value {
+		return nil, []error{fmt.Errorf("expected %s to be the canonical CIDR %s, got %s", key, ipNet.String(), value)}
+	}
+
+	return nil, nil
+}
+
+func expandRdbACLRules(raw interface{}) []*rdb.ACLRuleRequest {
+	rules := []*rdb.ACLRuleRequest{}
+	for _, rawRule := range raw.(*schema.Set).List() {
+		rule := rawRule.(map[string]interface{})
+		rules = append(rules, &rdb.ACLRuleRequest{
+			IP:          expandIPNet(rule["ip"].(string)),
+			Description: rule["description"].(string),
+		})
+	}
+
+	return rules
+}
+
+func flattenRdbACLRules(rules []*rdb.ACLRule) []map[string]interface{} {
+	res := []map[string]interface{}(nil)
+	for _, rule := range rules {
+		res = append(res, map[string]interface{}{
+			"ip":          flattenIPNet(rule.IP),
+			"description": rule.Description,
+		})
+	}
+
+	return res
+}
+
+func flattenRdbBackupSchedule(backupSchedule *rdb.BackupSchedule) []map[string]interface{} {
+	if backupSchedule == nil {
+		return nil
+	}
+
+	return []map[string]interface{}{
+		{
+			"frequency": int(backupSchedule.Frequency),
+			"retention": int(backupSchedule.Retention),
+		},
+	}
+}
+
+// waitRdbDatabaseBackup waits for the backup to leave its transient state and returns an error if it is not ready
+func waitRdbDatabaseBackup(ctx context.Context, rdbAPI *rdb.API, region scw.Region, backupID string) (*rdb.DatabaseBackup, error) {
+	backup, err := rdbAPI.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: backupID,
+		Timeout:          scw.TimeDurationPtr(defaultRdbInstanceTimeout),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if backup.Status != rdb.DatabaseBackupStatusReady {
+		return nil, fmt.Errorf("backup %s is %s instead of %s", backupID, backup.Status, rdb.DatabaseBackupStatusReady)
+	}
+
+	return backup, nil
+}
+
+// exportRdbDatabaseBackup exports the backup so that it gets a download URL
+func exportRdbDatabaseBackup(ctx context.Context, rdbAPI *rdb.API, region scw.Region, backupID string) error {
+	_, err := rdbAPI.ExportDatabaseBackup(&rdb.ExportDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: backupID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	_, err = waitRdbDatabaseBackup(ctx, rdbAPI, region, backupID)
+	return err
+}
@@ -0,0 +1,59 @@
+package scaleway
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRdbACLRuleIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       interface{}
+		expectedErr bool
+	}{
+		{
+			name:  "host",
+			value: "1.2.3.4/32",
+		},
+		{
+			name:  "network",
+			value: "10.0.0.0/8",
+		},
+		{
+			name:  "ipv6",
+			value: "2001:db8::/32",
+		},
+		{
+			name:        "ip without prefix length",
+			value:       "1.2.3.4",
+			expectedErr: true,
+		},
+		{
+			name:        "non canonical network",
+			value:       "10.1.2.3/8",
+			expectedErr: true,
+		},
+		{
+			name:        "invalid",
+			value:       "localhost",
+			expectedErr: true,
+		},
+		{
+			name:        "not a string",
+			value:       42,
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, errs := validateRdbACLRuleIP(test.value, "ip")
+			if test.expectedErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
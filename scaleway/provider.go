@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -60,8 +61,11 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_baremetal_server":              resourceScalewayBaremetalServer(),
 				"scaleway_instance_ip":                   resourceScalewayInstanceIP(),
 				"scaleway_instance_ip_reverse_dns":       resourceScalewayInstanceIPReverseDNS(),
+				"scaleway_instance_ip_attachment":        resourceScalewayInstanceIPAttachment(),
 				"scaleway_instance_volume":               resourceScalewayInstanceVolume(),
+				"scaleway_instance_volume_attachment":    resourceScalewayInstanceVolumeAttachment(),
 				"scaleway_instance_security_group":       resourceScalewayInstanceSecurityGroup(),
+				"scaleway_instance_security_group_rule":  resourceScalewayInstanceSecurityGroupRule(),
 				"scaleway_instance_security_group_rules": resourceScalewayInstanceSecurityGroupRules(),
 				"scaleway_instance_server":               resourceScalewayInstanceServer(),
 				"scaleway_instance_placement_group":      resourceScalewayInstancePlacementGroup(),
@@ -72,13 +76,18 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_iot_network":                   resourceScalewayIotNetwork(),
 				"scaleway_k8s_cluster":                   resourceScalewayK8SCluster(),
 				"scaleway_k8s_pool":                      resourceScalewayK8SPool(),
+				"scaleway_k8s_node_action":               resourceScalewayK8SNodeAction(),
 				"scaleway_lb":                            resourceScalewayLb(),
 				"scaleway_lb_ip":                         resourceScalewayLbIP(),
 				"scaleway_lb_backend":                    resourceScalewayLbBackend(),
 				"scaleway_lb_certificate":                resourceScalewayLbCertificate(),
 				"scaleway_lb_frontend":                   resourceScalewayLbFrontend(),
 				"scaleway_registry_namespace":            resourceScalewayRegistryNamespace(),
+				"scaleway_rdb_acl":                       resourceScalewayRdbACL(),
+				"scaleway_rdb_database":                  resourceScalewayRdbDatabase(),
+				"scaleway_rdb_database_backup":           resourceScalewayRdbDatabaseBackup(),
 				"scaleway_rdb_instance":                  resourceScalewayRdbInstance(),
+				"scaleway_rdb_privilege":                 resourceScalewayRdbPrivilege(),
 				"scaleway_rdb_user":                      resourceScalewayRdbUser(),
 				"scaleway_object_bucket":                 resourceScalewayObjectBucket(),
 				"scaleway_vpc_private_network":           resourceScalewayVPCPrivateNetwork(),
@@ -88,12 +97,17 @@ func Provider(config *ProviderConfig) plugin.ProviderFunc {
 				"scaleway_account_ssh_key":         dataSourceScalewayAccountSSHKey(),
 				"scaleway_instance_security_group": dataSourceScalewayInstanceSecurityGroup(),
 				"scaleway_instance_server":         dataSourceScalewayInstanceServer(),
+				"scaleway_instance_servers":        dataSourceScalewayInstanceServers(),
+				"scaleway_instance_server_types":   dataSourceScalewayInstanceServerTypes(),
 				"scaleway_instance_image":          dataSourceScalewayInstanceImage(),
 				"scaleway_instance_volume":         dataSourceScalewayInstanceVolume(),
 				"scaleway_baremetal_offer":         dataSourceScalewayBaremetalOffer(),
 				"scaleway_rdb_instance":            dataSourceScalewayRDBInstance(),
 				"scaleway_k8s_cluster":             dataSourceScalewayK8SCluster(),
+				"scaleway_k8s_kubeconfig":          dataSourceScalewayK8SKubeconfig(),
+				"scaleway_k8s_nodes":               dataSourceScalewayK8SNodes(),
 				"scaleway_k8s_pool":                dataSourceScalewayK8SPool(),
+				"scaleway_k8s_versions":            dataSourceScalewayK8SVersions(),
 				"scaleway_lb_ip":                   dataSourceScalewayLbIP(),
 				"scaleway_marketplace_image":       dataSourceScalewayMarketplaceImage(),
 				"scaleway_registry_namespace":      dataSourceScalewayRegistryNamespace(),
@@ -134,6 +148,8 @@ type Meta struct {
 	// or it can be a http.Client used to record and replay cassettes which is useful
 	// to replay recorded interactions with APIs locally
 	httpClient *http.Client
+	// instanceServerTypes caches the instance server types of each zone, see listInstanceServerTypes.
+	instanceServerTypes *sync.Map
 }
 
 type MetaConfig struct {
@@ -183,8 +199,9 @@ func buildMeta(config *MetaConfig) (*Meta, error) {
 	}
 
 	return &Meta{
-		scwClient:  scwClient,
-		httpClient: httpClient,
+		scwClient:           scwClient,
+		httpClient:          httpClient,
+		instanceServerTypes: &sync.Map{},
 	}, nil
 }
 
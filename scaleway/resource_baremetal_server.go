@@ -21,6 +21,7 @@ func resourceScalewayBaremetalServer() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		SchemaVersion: 0,
+		CustomizeDiff: customizeDiffBaremetalServerEstimatedMonthlyCost,
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultBaremetalServerTimeout),
 		},
@@ -47,6 +48,11 @@ func resourceScalewayBaremetalServer() *schema.Resource {
 				Computed:    true,
 				Description: "ID of the server offer",
 			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly cost of the server in Euro",
+			},
 			"os": {
 				Type:         schema.TypeString,
 				Required:     true,
@@ -128,10 +134,7 @@ func resourceScalewayBaremetalServerCreate(ctx context.Context, d *schema.Resour
 
 	offerID := expandZonedID(d.Get("offer"))
 	if !sdkValidation.IsUUID(offerID.ID) {
-		o, err := baremetalAPI.GetOfferByName(&baremetal.GetOfferByNameRequest{
-			OfferName: offerID.ID,
-			Zone:      zone,
-		})
+		o, err := getBaremetalOfferByName(ctx, baremetalAPI, zone, offerID.ID)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -215,6 +218,7 @@ func resourceScalewayBaremetalServerRead(ctx context.Context, d *schema.Resource
 	_ = d.Set("organization_id", server.OrganizationID)
 	_ = d.Set("project_id", server.ProjectID)
 	_ = d.Set("offer_id", newZonedID(server.Zone, offer.ID).String())
+	_ = d.Set("estimated_monthly_cost", flattenBaremetalOfferMonthlyPrice(offer))
 	_ = d.Set("tags", server.Tags)
 	_ = d.Set("domain", server.Domain)
 	_ = d.Set("ips", flattenBaremetalIPs(server.IPs))
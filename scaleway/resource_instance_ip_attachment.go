@@ -0,0 +1,134 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceIPAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceIPAttachmentCreate,
+		ReadContext:   resourceScalewayInstanceIPAttachmentRead,
+		DeleteContext: resourceScalewayInstanceIPAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceIPTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"ip_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "The reserved IP to attach",
+			},
+			"server_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "The server the IP is attached to",
+			},
+			"zone": zoneSchema(),
+		},
+	}
+}
+
+func resourceScalewayInstanceIPAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ipID := expandZonedID(d.Get("ip_id")).ID
+	serverID := expandZonedID(d.Get("server_id")).ID
+
+	// An IP attached to another server is moved in a single call.
+	_, err = instanceAPI.UpdateIP(&instance.UpdateIPRequest{
+		Zone:   zone,
+		IP:     ipID,
+		Server: &instance.NullableStringValue{Value: serverID},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedNestedIDString(zone, serverID, ipID))
+
+	return resourceScalewayInstanceIPAttachmentRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceIPAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ipID, serverID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetIP(&instance.GetIPRequest{
+		Zone: zone,
+		IP:   ipID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		// We check for 403 because instance API returns 403 for a deleted IP
+		if is404Error(err) || is403Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// The attachment is gone when the IP was detached or moved to another server.
+	if res.IP.Server == nil || res.IP.Server.ID != serverID {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("ip_id", newZonedIDString(zone, ipID))
+	_ = d.Set("server_id", newZonedIDString(zone, serverID))
+	_ = d.Set("zone", zone)
+
+	return nil
+}
+
+func resourceScalewayInstanceIPAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ipID, serverID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := instanceAPI.GetIP(&instance.GetIPRequest{
+		Zone: zone,
+		IP:   ipID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) || is403Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// The IP is only detached if it is still attached to this server:
+	// with create_before_destroy it has already been moved to the new server.
+	if res.IP.Server == nil || res.IP.Server.ID != serverID {
+		return nil
+	}
+
+	_, err = instanceAPI.UpdateIP(&instance.UpdateIPRequest{
+		Zone:   zone,
+		IP:     ipID,
+		Server: &instance.NullableStringValue{Null: true},
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) && !is403Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package scaleway
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -21,8 +23,11 @@ func resourceScalewayInstancePlacementGroup() *schema.Resource {
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstancePlacementGroupTimeout),
+			// Enforcing the policy may stop and start servers.
+			Update: schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
 		},
 		SchemaVersion: 0,
+		CustomizeDiff: customizeDiffInstancePlacementGroupEnforcePolicy,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -55,6 +60,17 @@ func resourceScalewayInstancePlacementGroup() *schema.Resource {
 				Computed:    true,
 				Description: "Is true when the policy is respected.",
 			},
+			"enforce_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     instancePlacementGroupEnforcePolicyNone,
+				Description: "What to do when the policy is not respected: none, fail or retry",
+				ValidateFunc: validation.StringInSlice([]string{
+					instancePlacementGroupEnforcePolicyNone,
+					instancePlacementGroupEnforcePolicyFail,
+					instancePlacementGroupEnforcePolicyRetry,
+				}, false),
+			},
 			"zone":            zoneSchema(),
 			"organization_id": organizationIDSchema(),
 			"project_id":      projectIDSchema(),
@@ -80,6 +96,7 @@ func resourceScalewayInstancePlacementGroupCreate(ctx context.Context, d *schema
 	}
 
 	d.SetId(newZonedIDString(zone, res.PlacementGroup.ID))
+
 	return resourceScalewayInstancePlacementGroupRead(ctx, d, meta)
 }
 
@@ -110,7 +127,31 @@ func resourceScalewayInstancePlacementGroupRead(ctx context.Context, d *schema.R
 	_ = d.Set("policy_type", res.PlacementGroup.PolicyType.String())
 	_ = d.Set("policy_respected", res.PlacementGroup.PolicyRespected)
 
-	return nil
+	if !isPlacementGroupPolicyEnforced(d.Get("enforce_policy").(string)) {
+		return nil
+	}
+
+	servers, err := listPlacementGroupServers(ctx, instanceAPI, zone, ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	diags := placementGroupServersDiagnostics(res.PlacementGroup, servers)
+	if unrespectedServers := filterPlacementGroupUnrespectedServers(servers); len(unrespectedServers) > 0 {
+		// With the retry enforce policy, the policy is enforced by the next apply, see customizeDiffInstancePlacementGroupEnforcePolicy.
+		_ = d.Set("policy_respected", false)
+		names := []string(nil)
+		for _, server := range unrespectedServers {
+			names = append(names, server.Name)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("placement group %s policy is not respected", res.PlacementGroup.Name),
+			Detail:   fmt.Sprintf("placement group %s policy is not respected for servers: %s", ID, strings.Join(names, ", ")),
+		})
+	}
+
+	return diags
 }
 
 func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -149,6 +190,13 @@ func resourceScalewayInstancePlacementGroupUpdate(ctx context.Context, d *schema
 		}
 	}
 
+	if enforcePolicy := d.Get("enforce_policy").(string); isPlacementGroupPolicyEnforced(enforcePolicy) {
+		err = enforcePlacementGroupPolicy(ctx, instanceAPI, zone, ID, enforcePolicy)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceScalewayInstancePlacementGroupRead(ctx, d, meta)
 }
 
@@ -169,3 +217,29 @@ func resourceScalewayInstancePlacementGroupDelete(ctx context.Context, d *schema
 
 	return nil
 }
+
+// customizeDiffInstancePlacementGroupEnforcePolicy plans an update of the placement groups whose policy is not
+// respected, so that the policy is enforced again by the apply.
+//
+// The update is only planned with the retry enforce policy and when the placement group does not hold too many
+// servers, as the apply cannot enforce the policy otherwise: the violation is then reported as a warning on read.
+// The policy is enforced on every update of the placement group, and by the servers joining it,
+// see enforceInstanceServerPlacementGroupPolicy.
+func customizeDiffInstancePlacementGroupEnforcePolicy(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() == "" || diff.Get("enforce_policy").(string) != instancePlacementGroupEnforcePolicyRetry || diff.Get("policy_respected").(bool) {
+		return nil
+	}
+
+	instanceAPI, zone, ID, err := instanceAPIWithZoneAndID(meta, diff.Id())
+	if err != nil {
+		return err
+	}
+	servers, err := listPlacementGroupServers(ctx, instanceAPI, zone, ID)
+	if err != nil {
+		return err
+	}
+	if placementGroupHasTooManyServers(instance.PlacementGroupPolicyType(diff.Get("policy_type").(string)), len(servers)) {
+		return nil
+	}
+	return diff.SetNew("policy_respected", true)
+}
@@ -24,6 +24,7 @@ func resourceScalewayInstanceSecurityGroup() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupTimeout),
 		},
+		CustomizeDiff: customizeDiffSecurityGroupRules,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -76,11 +77,25 @@ func resourceScalewayInstanceSecurityGroup() *schema.Resource {
 				Elem:          securityGroupRuleSchema(),
 				ConflictsWith: []string{"external_rules"},
 			},
+			"inbound_rule_set": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "Order insensitive inbound rules for this security group",
+				Elem:          securityGroupRuleSchema(),
+				ConflictsWith: []string{"external_rules"},
+			},
+			"outbound_rule_set": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Description:   "Order insensitive outbound rules for this security group",
+				Elem:          securityGroupRuleSchema(),
+				ConflictsWith: []string{"external_rules"},
+			},
 			"external_rules": {
 				Type:          schema.TypeBool,
 				Optional:      true,
 				Default:       false,
-				ConflictsWith: []string{"inbound_rule", "outbound_rule"},
+				ConflictsWith: []string{"inbound_rule", "outbound_rule", "inbound_rule_set", "outbound_rule_set"},
 			},
 			"enable_default_security": {
 				Type:        schema.TypeBool,
@@ -153,37 +168,71 @@ func resourceScalewayInstanceSecurityGroupRead(ctx context.Context, d *schema.Re
 	_ = d.Set("enable_default_security", res.SecurityGroup.EnableDefaultSecurity)
 
 	if !d.Get("external_rules").(bool) {
-		inboundRules, outboundRules, err := getSecurityGroupRules(ctx, instanceAPI, zone, ID, d)
+		err = readSecurityGroupRules(ctx, instanceAPI, zone, ID, d)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		_ = d.Set("inbound_rule", inboundRules)
-		_ = d.Set("outbound_rule", outboundRules)
 	}
 	return nil
 }
 
-func getSecurityGroupRules(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, securityGroupID string, d *schema.ResourceData) ([]interface{}, []interface{}, error) {
+// readSecurityGroupRules reads the rules of a security group into the rule attributes of the state.
+func readSecurityGroupRules(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, securityGroupID string, d *schema.ResourceData) error {
+	apiRules, err := listSecurityGroupEditableRules(ctx, instanceAPI, zone, securityGroupID)
+	if err != nil {
+		return err
+	}
+
+	// Sources are resolved again so that changes in the matching servers show up as a diff.
+	sources, err := listSecurityGroupRuleSources(ctx, instanceAPI, zone, d)
+	if err != nil {
+		return err
+	}
+
+	inboundList, inboundSet, err := securityGroupRulesCollapse(
+		apiRules[instance.SecurityGroupRuleDirectionInbound],
+		d.Get("inbound_rule").([]interface{}),
+		d.Get("inbound_rule_set").(*schema.Set).List(),
+		sources.expand,
+	)
+	if err != nil {
+		return err
+	}
+	outboundList, outboundSet, err := securityGroupRulesCollapse(
+		apiRules[instance.SecurityGroupRuleDirectionOutbound],
+		d.Get("outbound_rule").([]interface{}),
+		d.Get("outbound_rule_set").(*schema.Set).List(),
+		sources.expand,
+	)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("inbound_rule", inboundList)
+	_ = d.Set("outbound_rule", outboundList)
+	_ = d.Set("inbound_rule_set", inboundSet)
+	_ = d.Set("outbound_rule_set", outboundSet)
+
+	return nil
+}
+
+// listSecurityGroupEditableRules returns the editable rules of a security group by direction, ordered by position.
+func listSecurityGroupEditableRules(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, securityGroupID string) (map[instance.SecurityGroupRuleDirection][]*instance.SecurityGroupRule, error) {
 	resRules, err := instanceAPI.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
 		Zone:            zone,
 		SecurityGroupID: expandID(securityGroupID),
 	}, scw.WithAllPages(), scw.WithContext(ctx))
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	sort.Slice(resRules.Rules, func(i, j int) bool {
 		return resRules.Rules[i].Position < resRules.Rules[j].Position
 	})
+
 	apiRules := map[instance.SecurityGroupRuleDirection][]*instance.SecurityGroupRule{
 		instance.SecurityGroupRuleDirectionInbound:  {},
 		instance.SecurityGroupRuleDirectionOutbound: {},
 	}
-
-	stateRules := map[instance.SecurityGroupRuleDirection][]interface{}{
-		instance.SecurityGroupRuleDirectionInbound:  d.Get("inbound_rule").([]interface{}),
-		instance.SecurityGroupRuleDirectionOutbound: d.Get("outbound_rule").([]interface{}),
-	}
-
 	for _, apiRule := range resRules.Rules {
 		if !apiRule.Editable {
 			continue
@@ -191,21 +240,77 @@ func getSecurityGroupRules(ctx context.Context, instanceAPI *instance.API, zone
 		apiRules[apiRule.Direction] = append(apiRules[apiRule.Direction], apiRule)
 	}
 
-	// We make sure that we keep state rule if they match their api rule.
-	for direction := range apiRules {
-		for index, apiRule := range apiRules[direction] {
-			if index < len(stateRules[direction]) {
-				stateRule := securityGroupRuleExpand(stateRules[direction][index])
-				if !securityGroupRuleEquals(stateRule, apiRule) {
-					stateRules[direction][index] = securityGroupRuleFlatten(apiRule)
+	return apiRules, nil
+}
+
+// securityGroupRulesCollapse matches api rules with the state rules they were expanded from.
+//
+// It works as followed:
+//   1) Rules of the state list are compared with the first api rules in the same order.
+//      A state rule is kept if all its api rules match, otherwise the api rule at this position is flattened.
+//   2) Rules of the state set may match the remaining api rules in any order.
+//      We keep the state rules whose api rules are all found.
+//   3) Api rules that are not matched are flattened one by one so the difference shows up in the plan.
+//      They are added to the set if it is the only one used, to the list otherwise.
+func securityGroupRulesCollapse(apiRules []*instance.SecurityGroupRule, stateList []interface{}, stateSet []interface{}, expand func(interface{}) ([]*instance.SecurityGroupRule, error)) ([]interface{}, []interface{}, error) {
+	list := []interface{}(nil)
+	cursor := 0
+	for _, rawRule := range stateList {
+		expandedRules, err := expand(rawRule)
+		if err != nil {
+			return nil, nil, err
+		}
+		// A rule whose sources match no server is never kept, so that the change shows up in the plan.
+		if len(expandedRules) > 0 && cursor+len(expandedRules) <= len(apiRules) && securityGroupRulesEquals(expandedRules, apiRules[cursor:cursor+len(expandedRules)]) {
+			list = append(list, rawRule)
+			cursor += len(expandedRules)
+			continue
+		}
+		if cursor >= len(apiRules) {
+			break
+		}
+		list = append(list, securityGroupRuleFlatten(apiRules[cursor]))
+		cursor++
+	}
+
+	remainingRules := apiRules[cursor:]
+	matched := make([]bool, len(remainingRules))
+	set := []interface{}(nil)
+	for _, rawRule := range stateSet {
+		expandedRules, err := expand(rawRule)
+		if err != nil {
+			return nil, nil, err
+		}
+		used := append([]bool(nil), matched...)
+		found := 0
+		for _, expandedRule := range expandedRules {
+			for i, apiRule := range remainingRules {
+				if !used[i] && securityGroupRuleEquals(expandedRule, apiRule) {
+					used[i] = true
+					found++
+					break
 				}
-			} else {
-				stateRules[direction] = append(stateRules[direction], securityGroupRuleFlatten(apiRule))
 			}
 		}
+		if len(expandedRules) == 0 || found != len(expandedRules) {
+			continue
+		}
+		matched = used
+		set = append(set, rawRule)
+	}
+
+	for i, apiRule := range remainingRules {
+		if matched[i] {
+			continue
+		}
+		if len(stateList) == 0 && len(stateSet) > 0 {
+			set = append(set, securityGroupRuleFlatten(apiRule))
+		} else {
+			list = append(list, securityGroupRuleFlatten(apiRule))
+		}
 	}
 
-	return stateRules[instance.SecurityGroupRuleDirectionInbound], stateRules[instance.SecurityGroupRuleDirectionOutbound], nil
+	return list, set, nil
 }
 
 func resourceScalewayInstanceSecurityGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -268,58 +373,53 @@ func resourceScalewayInstanceSecurityGroupUpdate(ctx context.Context, d *schema.
 //
 // It works as followed:
 //   1) Creates 2 map[direction][]rule: one for rules in state and one for rules in API
+//      State rules are expanded to one api rule per ip range: rules of the list first, then rules of the set.
+//      Rule sources are resolved to the ips of the matching servers.
 //   2) For each direction we:
-//     A) Loop for each rule in state for this direction
+//     A) Loop for each rule expanded from the state list for this direction
 //       a) Compare with api rule in this direction at the same index
 //          if different update / if equals do nothing / if no more api rules to compare create new api rule
-//     B) If there is more rule in the API we remove them
+//     B) Match rules expanded from the state set with the remaining api rules in any order
+//       a) Api rules equal to a state rule are kept
+//       b) Other api rules are updated to match the other state rules, created or removed
 func updateSecurityGroupeRules(ctx context.Context, d *schema.ResourceData, zone scw.Zone, securityGroupID string, instanceAPI *instance.API) error {
-	apiRules := map[instance.SecurityGroupRuleDirection][]*instance.SecurityGroupRule{
-		instance.SecurityGroupRuleDirectionInbound:  {},
-		instance.SecurityGroupRuleDirectionOutbound: {},
+	mutex := lockInstanceSecurityGroup(zone, securityGroupID)
+	defer mutex.Unlock()
+
+	sources, err := listSecurityGroupRuleSources(ctx, instanceAPI, zone, d)
+	if err != nil {
+		return err
 	}
-	stateRules := map[instance.SecurityGroupRuleDirection][]interface{}{
-		instance.SecurityGroupRuleDirectionInbound:  d.Get("inbound_rule").([]interface{}),
-		instance.SecurityGroupRuleDirectionOutbound: d.Get("outbound_rule").([]interface{}),
+
+	stateListRules := map[instance.SecurityGroupRuleDirection][]*instance.SecurityGroupRule{}
+	stateSetRules := map[instance.SecurityGroupRuleDirection][]*instance.SecurityGroupRule{}
+	for direction, prefix := range map[instance.SecurityGroupRuleDirection]string{
+		instance.SecurityGroupRuleDirectionInbound:  "inbound",
+		instance.SecurityGroupRuleDirectionOutbound: "outbound",
+	} {
+		stateListRules[direction], err = sources.expandAll(d.Get(prefix + "_rule").([]interface{}))
+		if err != nil {
+			return err
+		}
+		stateSetRules[direction], err = sources.expandAll(d.Get(prefix + "_rule_set").(*schema.Set).List())
+		if err != nil {
+			return err
+		}
 	}
 
 	// Fill apiRules with data from API
-	resRules, err := instanceAPI.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
-		Zone:            zone,
-		SecurityGroupID: expandID(securityGroupID),
-	}, scw.WithAllPages(), scw.WithContext(ctx))
+	apiRules, err := listSecurityGroupEditableRules(ctx, instanceAPI, zone, securityGroupID)
 	if err != nil {
 		return err
 	}
-	sort.Slice(resRules.Rules, func(i, j int) bool {
-		return resRules.Rules[i].Position < resRules.Rules[j].Position
-	})
-	for _, apiRule := range resRules.Rules {
-		if !apiRule.Editable {
-			continue
-		}
-		apiRules[apiRule.Direction] = append(apiRules[apiRule.Direction], apiRule)
-	}
 
 	// Loop through all directions
-	for direction := range stateRules {
-		// Loop for all state rules in this direction
-		for index, rawStateRule := range stateRules[direction] {
-			apiRule := (*instance.SecurityGroupRule)(nil)
-			stateRule := securityGroupRuleExpand(rawStateRule)
-
+	for direction := range stateListRules {
+		// Loop for all rules expanded from the state list in this direction
+		for index, stateRule := range stateListRules[direction] {
 			// This happen when there is more rule in state than in the api. We create more rule in API.
 			if index >= len(apiRules[direction]) {
-				_, err = instanceAPI.CreateSecurityGroupRule(&instance.CreateSecurityGroupRuleRequest{
-					Zone:            zone,
-					SecurityGroupID: securityGroupID,
-					Protocol:        stateRule.Protocol,
-					IPRange:         stateRule.IPRange,
-					Action:          stateRule.Action,
-					DestPortTo:      stateRule.DestPortTo,
-					DestPortFrom:    stateRule.DestPortFrom,
-					Direction:       direction,
-				}, scw.WithContext(ctx))
+				err = createSecurityGroupRule(ctx, instanceAPI, zone, securityGroupID, direction, stateRule)
 				if err != nil {
 					return err
 				}
@@ -327,41 +427,61 @@ func updateSecurityGroupeRules(ctx context.Context, d *schema.ResourceData, zone
 			}
 
 			// We compare rule stateRule[index] and apiRule[index]. If they are different we update api rule to match state.
-			apiRule = apiRules[direction][index]
+			apiRule := apiRules[direction][index]
 			if !securityGroupRuleEquals(stateRule, apiRule) {
-				destPortFrom := stateRule.DestPortFrom
-				destPortTo := stateRule.DestPortTo
-				if destPortFrom == nil {
-					destPortFrom = scw.Uint32Ptr(0)
+				err = updateSecurityGroupRule(ctx, instanceAPI, zone, securityGroupID, direction, apiRule.ID, stateRule)
+				if err != nil {
+					return err
 				}
-				if destPortTo == nil {
-					destPortTo = scw.Uint32Ptr(0)
+			}
+		}
+
+		// Remaining api rules are matched with the rules expanded from the state set.
+		remainingRules := []*instance.SecurityGroupRule(nil)
+		if len(stateListRules[direction]) < len(apiRules[direction]) {
+			remainingRules = apiRules[direction][len(stateListRules[direction]):]
+		}
+
+		// We keep api rules that are equal to a state rule.
+		unmatchedStateRules := []*instance.SecurityGroupRule(nil)
+		matched := make([]bool, len(remainingRules))
+		for _, stateRule := range stateSetRules[direction] {
+			found := false
+			for i, apiRule := range remainingRules {
+				if !matched[i] && securityGroupRuleEquals(stateRule, apiRule) {
+					matched[i] = true
+					found = true
+					break
 				}
+			}
+			if !found {
+				unmatchedStateRules = append(unmatchedStateRules, stateRule)
+			}
+		}
 
-				_, err = instanceAPI.UpdateSecurityGroupRule(&instance.UpdateSecurityGroupRuleRequest{
+		// Other api rules are updated to match remaining state rules or removed as they are no longer in the state.
+		for i, apiRule := range remainingRules {
+			if matched[i] {
+				continue
+			}
+			if len(unmatchedStateRules) > 0 {
+				err = updateSecurityGroupRule(ctx, instanceAPI, zone, securityGroupID, direction, apiRule.ID, unmatchedStateRules[0])
+				unmatchedStateRules = unmatchedStateRules[1:]
+			} else {
+				err = instanceAPI.DeleteSecurityGroupRule(&instance.DeleteSecurityGroupRuleRequest{
 					Zone:                zone,
 					SecurityGroupID:     securityGroupID,
 					SecurityGroupRuleID: apiRule.ID,
-					Protocol:            &stateRule.Protocol,
-					IPRange:             &stateRule.IPRange,
-					Action:              &stateRule.Action,
-					DestPortTo:          destPortTo,
-					DestPortFrom:        destPortFrom,
-					Direction:           &direction,
 				}, scw.WithContext(ctx))
-				if err != nil {
-					return err
-				}
+			}
+			if err != nil {
+				return err
 			}
 		}
 
-		// We loop through remaining API rules and delete them as they are no longer in the state.
-		for index := len(stateRules[direction]); index < len(apiRules[direction]); index++ {
-			err = instanceAPI.DeleteSecurityGroupRule(&instance.DeleteSecurityGroupRuleRequest{
-				Zone:                zone,
-				SecurityGroupID:     securityGroupID,
-				SecurityGroupRuleID: apiRules[direction][index].ID,
-			}, scw.WithContext(ctx))
+		// We create state rules that could not be matched with an existing api rule.
+		for _, stateRule := range unmatchedStateRules {
+			err = createSecurityGroupRule(ctx, instanceAPI, zone, securityGroupID, direction, stateRule)
 			if err != nil {
 				return err
 			}
@@ -370,6 +490,46 @@ func updateSecurityGroupeRules(ctx context.Context, d *schema.ResourceData, zone
 	return nil
 }
 
+// createSecurityGroupRule creates an api rule from an expanded state rule.
+func createSecurityGroupRule(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, securityGroupID string, direction instance.SecurityGroupRuleDirection, rule *instance.SecurityGroupRule) error {
+	_, err := instanceAPI.CreateSecurityGroupRule(&instance.CreateSecurityGroupRuleRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+		Protocol:        rule.Protocol,
+		IPRange:         rule.IPRange,
+		Action:          rule.Action,
+		DestPortTo:      rule.DestPortTo,
+		DestPortFrom:    rule.DestPortFrom,
+		Direction:       direction,
+	}, scw.WithContext(ctx))
+	return err
+}
+
+// updateSecurityGroupRule updates an api rule to match an expanded state rule.
+func updateSecurityGroupRule(ctx context.Context, instanceAPI *instance.API, zone scw.Zone, securityGroupID string, direction instance.SecurityGroupRuleDirection, ruleID string, rule *instance.SecurityGroupRule) error {
+	destPortFrom := rule.DestPortFrom
+	destPortTo := rule.DestPortTo
+	if destPortFrom == nil {
+		destPortFrom = scw.Uint32Ptr(0)
+	}
+	if destPortTo == nil {
+		destPortTo = scw.Uint32Ptr(0)
+	}
+
+	_, err := instanceAPI.UpdateSecurityGroupRule(&instance.UpdateSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+		Protocol:            &rule.Protocol,
+		IPRange:             &rule.IPRange,
+		Action:              &rule.Action,
+		DestPortTo:          destPortTo,
+		DestPortFrom:        destPortFrom,
+		Direction:           &direction,
+	}, scw.WithContext(ctx))
+	return err
+}
+
 func resourceScalewayInstanceSecurityGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	instanceAPI, _, err := instanceAPIWithZone(d, meta)
 	if err != nil {
@@ -423,9 +583,10 @@ func securityGroupRuleSchema() *schema.Resource {
 				Description: "Network port for this rule",
 			},
 			"port_range": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Computed port range for this rule (e.g: 1-1024, 22-22)",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSecurityGroupRulePortRange(),
+				Description:  "Port range for this rule (e.g: 1-1024, 22-22)",
 			},
 			"ip": {
 				Type:         schema.TypeString,
@@ -439,52 +600,95 @@ func securityGroupRuleSchema() *schema.Resource {
 				ValidateFunc: validation.IsCIDRNetwork(0, 128),
 				Description:  "Ip range for this rule (e.g: 192.168.1.0/24). Only one of ip or ip_range should be provided",
 			},
+			"ip_ranges": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Ip ranges for this rule, one api rule is created per ip range. Conflicts with ip and ip_range",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDRNetwork(0, 128),
+				},
+			},
+			"source_security_group_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "Security group of the servers this rule applies to. Resolved to the ips of the servers",
+			},
+			"source_server_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Tags of the servers this rule applies to. Resolved to the ips of the servers",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
 
-// securityGroupRuleExpand transform a state rule to an api one.
-func securityGroupRuleExpand(i interface{}) *instance.SecurityGroupRule {
+// securityGroupRuleExpand transform a state rule to the api ones, one per ip range.
+func securityGroupRuleExpand(i interface{}) ([]*instance.SecurityGroupRule, error) {
 	rawRule := i.(map[string]interface{})
 
 	portFrom, portTo := uint32(0), uint32(0)
 
 	portRange := rawRule["port_range"].(string)
 	if portRange != "" {
-		_, _ = fmt.Sscanf(portRange, "%d-%d", &portFrom, &portTo)
+		var err error
+		portFrom, portTo, err = parseSecurityGroupRulePortRange(portRange)
+		if err != nil {
+			return nil, err
+		}
 	} else {
 		portFrom = uint32(rawRule["port"].(int))
 		portTo = portFrom
 	}
 
 	action, _ := rawRule["action"].(string)
-	ipRange := rawRule["ip_range"].(string)
-	if ipRange == "" {
-		ipRange = rawRule["ip"].(string) + "/32"
+
+	ipRanges := []string(nil)
+	if rawIPRanges, ok := rawRule["ip_ranges"].([]interface{}); ok {
+		for _, rawIPRange := range rawIPRanges {
+			ipRanges = append(ipRanges, rawIPRange.(string))
+		}
 	}
-	if ipRange == "/32" {
-		ipRange = "0.0.0.0/0"
+	if len(ipRanges) == 0 {
+		ipRange := rawRule["ip_range"].(string)
+		if ipRange == "" && rawRule["ip"].(string) != "" {
+			ipRange = securityGroupRuleIPRange(rawRule["ip"].(string))
+		}
+		if ipRange == "" {
+			ipRange = "0.0.0.0/0"
+		}
+		ipRanges = []string{ipRange}
 	}
 
-	rule := &instance.SecurityGroupRule{
-		DestPortFrom: &portFrom,
-		DestPortTo:   &portTo,
-		Protocol:     instance.SecurityGroupRuleProtocol(rawRule["protocol"].(string)),
-		IPRange:      expandIPNet(ipRange),
-		Action:       instance.SecurityGroupRuleAction(action),
-	}
+	rules := make([]*instance.SecurityGroupRule, 0, len(ipRanges))
+	for _, ipRange := range ipRanges {
+		rule := &instance.SecurityGroupRule{
+			DestPortFrom: scw.Uint32Ptr(portFrom),
+			DestPortTo:   scw.Uint32Ptr(portTo),
+			Protocol:     instance.SecurityGroupRuleProtocol(rawRule["protocol"].(string)),
+			IPRange:      expandIPNet(ipRange),
+			Action:       instance.SecurityGroupRuleAction(action),
+		}
 
-	if *rule.DestPortFrom == *rule.DestPortTo {
-		rule.DestPortTo = nil
-	}
+		if *rule.DestPortFrom == *rule.DestPortTo {
+			rule.DestPortTo = nil
+		}
+
+		// Handle when no port is specified.
+		if portFrom == 0 && portTo == 0 {
+			rule.DestPortFrom = nil
+			rule.DestPortTo = nil
+		}
 
-	// Handle when no port is specified.
-	if portFrom == 0 && portTo == 0 {
-		rule.DestPortFrom = nil
-		rule.DestPortTo = nil
+		rules = append(rules, rule)
 	}
 
-	return rule
+	return rules, nil
 }
 
 // securityGroupRuleFlatten transform a api rule to an state one.
@@ -526,3 +730,16 @@ func securityGroupRuleEquals(ruleA, ruleB *instance.SecurityGroupRule) bool {
 		ipEqual &&
 		ruleA.Protocol == ruleB.Protocol
 }
+
+// securityGroupRulesEquals compares two lists of security group rules in order.
+func securityGroupRulesEquals(rulesA, rulesB []*instance.SecurityGroupRule) bool {
+	if len(rulesA) != len(rulesB) {
+		return false
+	}
+	for i := range rulesA {
+		if !securityGroupRuleEquals(rulesA[i], rulesB[i]) {
+			return false
+		}
+	}
+	return true
+}
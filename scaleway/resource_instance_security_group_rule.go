@@ -0,0 +1,287 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceSecurityGroupRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceSecurityGroupRuleCreate,
+		ReadContext:   resourceScalewayInstanceSecurityGroupRuleRead,
+		UpdateContext: resourceScalewayInstanceSecurityGroupRuleUpdate,
+		DeleteContext: resourceScalewayInstanceSecurityGroupRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "The security group the rule belongs to",
+			},
+			"direction": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleDirectionInbound.String(),
+					instance.SecurityGroupRuleDirectionOutbound.String(),
+				}, false),
+				Description: "Direction of the traffic this rule applies to (inbound or outbound)",
+			},
+			"action": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleActionAccept.String(),
+					instance.SecurityGroupRuleActionDrop.String(),
+				}, false),
+				Description: "Action when rule match request (drop or accept)",
+			},
+			"protocol": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  instance.SecurityGroupRuleProtocolTCP.String(),
+				ValidateFunc: validation.StringInSlice([]string{
+					instance.SecurityGroupRuleProtocolICMP.String(),
+					instance.SecurityGroupRuleProtocolTCP.String(),
+					instance.SecurityGroupRuleProtocolUDP.String(),
+					instance.SecurityGroupRuleProtocolANY.String(),
+				}, false),
+				Description: "Protocol for this rule (TCP, UDP, ICMP or ANY)",
+			},
+			"port": {
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"port_range"},
+				Description:   "Network port for this rule",
+			},
+			"port_range": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"port"},
+				ValidateFunc:  validateSecurityGroupRulePortRange(),
+				Description:   "Port range for this rule (e.g: 1-1024, 22-22)",
+			},
+			"ip": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsIPAddress,
+				ConflictsWith: []string{"ip_range"},
+				Description:   "Ip address for this rule (e.g: 1.1.1.1). Only one of ip or ip_range should be provided",
+			},
+			"ip_range": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.IsCIDRNetwork(0, 128),
+				ConflictsWith: []string{"ip"},
+				Description:   "Ip range for this rule (e.g: 192.168.1.0/24). Only one of ip or ip_range should be provided",
+			},
+			"position": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Position of the rule in the security group",
+			},
+			"zone": zoneSchema(),
+		},
+	}
+}
+
+func resourceScalewayInstanceSecurityGroupRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	securityGroupID := expandZonedID(d.Get("security_group_id")).ID
+
+	rules, err := securityGroupRuleExpand(map[string]interface{}{
+		"action":     d.Get("action"),
+		"protocol":   d.Get("protocol"),
+		"port":       d.Get("port"),
+		"port_range": d.Get("port_range"),
+		"ip":         d.Get("ip"),
+		"ip_range":   d.Get("ip_range"),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule := rules[0]
+
+	mutex := lockInstanceSecurityGroup(zone, securityGroupID)
+	defer mutex.Unlock()
+
+	res, err := instanceAPI.CreateSecurityGroupRule(&instance.CreateSecurityGroupRuleRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+		Protocol:        rule.Protocol,
+		Direction:       instance.SecurityGroupRuleDirection(d.Get("direction").(string)),
+		Action:          rule.Action,
+		IPRange:         rule.IPRange,
+		DestPortFrom:    rule.DestPortFrom,
+		DestPortTo:      rule.DestPortTo,
+		Position:        uint32(d.Get("position").(int)),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedNestedIDString(zone, securityGroupID, res.Rule.ID))
+
+	return resourceScalewayInstanceSecurityGroupRuleRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceSecurityGroupRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Rules are looked up in the rule list of the security group as other resources may edit it.
+	resRules, err := instanceAPI.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+		Zone:            zone,
+		SecurityGroupID: securityGroupID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var rule *instance.SecurityGroupRule
+	for _, apiRule := range resRules.Rules {
+		if apiRule.ID == ruleID {
+			rule = apiRule
+			break
+		}
+	}
+	if rule == nil {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("zone", zone)
+	_ = d.Set("security_group_id", newZonedIDString(zone, securityGroupID))
+	_ = d.Set("direction", rule.Direction.String())
+	_ = d.Set("action", rule.Action.String())
+	_ = d.Set("protocol", rule.Protocol.String())
+	_ = d.Set("position", int(rule.Position))
+
+	// Ports are read back in the attribute used in the configuration.
+	flatRule := securityGroupRuleFlatten(rule)
+	switch {
+	case rule.DestPortFrom == nil:
+		_ = d.Set("port", 0)
+		_ = d.Set("port_range", "")
+	case d.Get("port_range").(string) != "" || (rule.DestPortTo != nil && *rule.DestPortTo != *rule.DestPortFrom):
+		_ = d.Set("port", 0)
+		_ = d.Set("port_range", flatRule["port_range"])
+	default:
+		_ = d.Set("port", int(*rule.DestPortFrom))
+		_ = d.Set("port_range", "")
+	}
+
+	// IPs are read back in the attribute used in the configuration.
+	ipRange := flatRule["ip_range"].(string)
+	switch {
+	case d.Get("ip").(string) != "" && securityGroupRuleIPRangeIsIP(ipRange, d.Get("ip").(string)):
+		_ = d.Set("ip_range", "")
+	case d.Get("ip").(string) == "" && d.Get("ip_range").(string) == "" && ipRange == "0.0.0.0/0":
+		_ = d.Set("ip_range", "")
+	default:
+		_ = d.Set("ip", "")
+		_ = d.Set("ip_range", ipRange)
+	}
+
+	return nil
+}
+
+func resourceScalewayInstanceSecurityGroupRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rules, err := securityGroupRuleExpand(map[string]interface{}{
+		"action":     d.Get("action"),
+		"protocol":   d.Get("protocol"),
+		"port":       d.Get("port"),
+		"port_range": d.Get("port_range"),
+		"ip":         d.Get("ip"),
+		"ip_range":   d.Get("ip_range"),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule := rules[0]
+
+	// A zero port removes the port of the rule.
+	destPortFrom := rule.DestPortFrom
+	destPortTo := rule.DestPortTo
+	if destPortFrom == nil {
+		destPortFrom = scw.Uint32Ptr(0)
+	}
+	if destPortTo == nil {
+		destPortTo = scw.Uint32Ptr(0)
+	}
+
+	updateRequest := &instance.UpdateSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+		Protocol:            &rule.Protocol,
+		Action:              &rule.Action,
+		IPRange:             &rule.IPRange,
+		DestPortFrom:        destPortFrom,
+		DestPortTo:          destPortTo,
+	}
+
+	if d.HasChange("position") {
+		updateRequest.Position = scw.Uint32Ptr(uint32(d.Get("position").(int)))
+	}
+
+	mutex := lockInstanceSecurityGroup(zone, securityGroupID)
+	defer mutex.Unlock()
+
+	_, err = instanceAPI.UpdateSecurityGroupRule(updateRequest, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceScalewayInstanceSecurityGroupRuleRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceSecurityGroupRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, ruleID, securityGroupID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	mutex := lockInstanceSecurityGroup(zone, securityGroupID)
+	defer mutex.Unlock()
+
+	err = instanceAPI.DeleteSecurityGroupRule(&instance.DeleteSecurityGroupRuleRequest{
+		Zone:                zone,
+		SecurityGroupID:     securityGroupID,
+		SecurityGroupRuleID: ruleID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ func resourceScalewayInstanceSecurityGroupRules() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceSecurityGroupRuleTimeout),
 		},
+		CustomizeDiff: customizeDiffSecurityGroupRules,
 		Schema: map[string]*schema.Schema{
 			"security_group_id": {
 				Type:     schema.TypeString,
@@ -40,6 +41,18 @@ func resourceScalewayInstanceSecurityGroupRules() *schema.Resource {
 				Description: "Outbound rules for this set of security group rules",
 				Elem:        securityGroupRuleSchema(),
 			},
+			"inbound_rule_set": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Order insensitive inbound rules for this set of security group rules",
+				Elem:        securityGroupRuleSchema(),
+			},
+			"outbound_rule_set": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Order insensitive outbound rules for this set of security group rules",
+				Elem:        securityGroupRuleSchema(),
+			},
 		},
 	}
 }
@@ -61,14 +74,11 @@ func resourceScalewayInstanceSecurityGroupRulesRead(ctx context.Context, d *sche
 
 	_ = d.Set("security_group_id", securityGroupZonedID)
 
-	inboundRules, outboundRules, err := getSecurityGroupRules(ctx, instanceAPI, zone, securityGroupID, d)
+	err = readSecurityGroupRules(ctx, instanceAPI, zone, securityGroupID, d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	_ = d.Set("inbound_rule", inboundRules)
-	_ = d.Set("outbound_rule", outboundRules)
-
 	return nil
 }
 
@@ -96,6 +106,8 @@ func resourceScalewayInstanceSecurityGroupRulesDelete(ctx context.Context, d *sc
 
 	_ = d.Set("inbound_rule", nil)
 	_ = d.Set("outbound_rule", nil)
+	_ = d.Set("inbound_rule_set", nil)
+	_ = d.Set("outbound_rule_set", nil)
 
 	err = updateSecurityGroupeRules(ctx, d, zone, securityGroupID, instanceAPI)
 	if err != nil {
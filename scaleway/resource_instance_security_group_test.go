@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -539,6 +540,127 @@ func testSweepComputeInstanceSecurityGroup(_ string) error {
 	})
 }
 
+func TestSecurityGroupRuleExpand(t *testing.T) {
+	rules, err := securityGroupRuleExpand(map[string]interface{}{
+		"action":     "accept",
+		"protocol":   "TCP",
+		"port":       0,
+		"port_range": "8000-8100",
+		"ip":         "",
+		"ip_range":   "",
+		"ip_ranges":  []interface{}{"10.0.0.0/16", "192.168.0.0/24"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "10.0.0.0/16", flattenIPNet(rules[0].IPRange))
+	assert.Equal(t, "192.168.0.0/24", flattenIPNet(rules[1].IPRange))
+	for _, rule := range rules {
+		assert.Equal(t, uint32(8000), *rule.DestPortFrom)
+		assert.Equal(t, uint32(8100), *rule.DestPortTo)
+	}
+
+	rules, err = securityGroupRuleExpand(map[string]interface{}{
+		"action":     "drop",
+		"protocol":   "UDP",
+		"port":       53,
+		"port_range": "",
+		"ip":         "1.1.1.1",
+		"ip_range":   "",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "1.1.1.1/32", flattenIPNet(rules[0].IPRange))
+	assert.Equal(t, uint32(53), *rules[0].DestPortFrom)
+	assert.Nil(t, rules[0].DestPortTo)
+
+	// IPv6 addresses get the full prefix length of their address family.
+	rules, err = securityGroupRuleExpand(map[string]interface{}{
+		"action":     "accept",
+		"protocol":   "TCP",
+		"port":       22,
+		"port_range": "",
+		"ip":         "2001:db8::1",
+		"ip_range":   "",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "2001:db8::1/128", flattenIPNet(rules[0].IPRange))
+
+	// Invalid port ranges are reported instead of being expanded to a zero port.
+	_, err = securityGroupRuleExpand(map[string]interface{}{
+		"action":     "accept",
+		"protocol":   "TCP",
+		"port":       0,
+		"port_range": "22",
+		"ip":         "",
+		"ip_range":   "",
+	})
+	assert.Error(t, err)
+}
+
+func TestValidateSecurityGroupRulePortRange(t *testing.T) {
+	validate := validateSecurityGroupRulePortRange()
+	for _, portRange := range []string{"1-1024", "22-22", "1-65535"} {
+		_, errs := validate(portRange, "port_range")
+		assert.Empty(t, errs, portRange)
+	}
+	for _, portRange := range []string{"", "22", "0-22", "100-22", "1-65536", "a-b", "1-2-3", "-1-22"} {
+		_, errs := validate(portRange, "port_range")
+		assert.NotEmpty(t, errs, portRange)
+	}
+}
+
+func TestSecurityGroupRulesCollapse(t *testing.T) {
+	multiRangeRule := map[string]interface{}{
+		"action":     "accept",
+		"protocol":   "TCP",
+		"port":       22,
+		"port_range": "",
+		"ip":         "",
+		"ip_range":   "",
+		"ip_ranges":  []interface{}{"10.0.0.0/16", "192.168.0.0/24"},
+	}
+	singleRule := map[string]interface{}{
+		"action":     "drop",
+		"protocol":   "TCP",
+		"port":       80,
+		"port_range": "",
+		"ip":         "",
+		"ip_range":   "",
+		"ip_ranges":  []interface{}{},
+	}
+	singleAPIRules, err := securityGroupRuleExpand(singleRule)
+	assert.NoError(t, err)
+	multiRangeAPIRules, err := securityGroupRuleExpand(multiRangeRule)
+	assert.NoError(t, err)
+	apiRules := append(singleAPIRules, multiRangeAPIRules...)
+
+	// Set rules match api rules in any order.
+	list, set, err := securityGroupRulesCollapse(apiRules, nil, []interface{}{multiRangeRule, singleRule}, securityGroupRuleExpand)
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+	assert.Equal(t, []interface{}{multiRangeRule, singleRule}, set)
+
+	// List rules must match api rules in order.
+	list, set, err = securityGroupRulesCollapse(apiRules, []interface{}{multiRangeRule, singleRule}, nil, securityGroupRuleExpand)
+	assert.NoError(t, err)
+	assert.Len(t, set, 0)
+	assert.Len(t, list, 3)
+
+	list, set, err = securityGroupRulesCollapse(apiRules, []interface{}{singleRule, multiRangeRule}, nil, securityGroupRuleExpand)
+	assert.NoError(t, err)
+	assert.Len(t, set, 0)
+	assert.Equal(t, []interface{}{singleRule, multiRangeRule}, list)
+
+	// Unknown api rules are flattened in the set when only the set is used.
+	list, set, err = securityGroupRulesCollapse(apiRules, nil, []interface{}{multiRangeRule}, securityGroupRuleExpand)
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+	assert.Len(t, set, 2)
+	assert.Equal(t, multiRangeRule, set[0])
+	assert.Equal(t, "0.0.0.0/0", set[1].(map[string]interface{})["ip_range"])
+}
+
 func TestAccScalewayInstanceSecurityGroup_EnableDefaultSecurity(t *testing.T) {
 	tt := NewTestTools(t)
 	defer tt.Cleanup()
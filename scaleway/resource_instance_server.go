@@ -1,18 +1,16 @@
 package scaleway
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"io"
 	"io/ioutil"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
-	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 	scwvalidation "github.com/scaleway/scaleway-sdk-go/validation"
 )
@@ -29,6 +27,11 @@ func resourceScalewayInstanceServer() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Default: schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffInstanceServerImage,
+			customizeDiffInstanceServerEstimatedMonthlyCost,
+			customizeDiffInstanceServerPlacementGroup,
+		),
 		SchemaVersion: 0,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -44,6 +47,22 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				Description:      "The UUID or the label of the base image used by the server",
 				DiffSuppressFunc: diffSuppressFuncLocality,
 			},
+			"image_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The UUID of the local image the server was created from",
+			},
+			"image_update_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     instanceImageUpdatePolicyIgnore,
+				Description: "What to do when the image label points to a newer image than the one the server was created from",
+				ValidateFunc: validation.StringInSlice([]string{
+					instanceImageUpdatePolicyIgnore,
+					instanceImageUpdatePolicyWarn,
+					instanceImageUpdatePolicyReplace,
+				}, false),
+			},
 			"type": {
 				Type:             schema.TypeString,
 				Required:         true,
@@ -72,11 +91,27 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				DiffSuppressFunc: diffSuppressFuncLocality,
 				Description:      "The placement group the server is attached to",
 			},
+			"placement_group_enforce_policy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     instancePlacementGroupEnforcePolicyNone,
+				Description: "What to do when the placement group policy is not respected for the server: none, fail or retry",
+				ValidateFunc: validation.StringInSlice([]string{
+					instancePlacementGroupEnforcePolicyNone,
+					instancePlacementGroupEnforcePolicyFail,
+					instancePlacementGroupEnforcePolicyRetry,
+				}, false),
+			},
 			"placement_group_policy_respected": {
 				Type:        schema.TypeBool,
 				Computed:    true,
 				Description: "True when the placement group policy is respected",
 			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly cost of the server in Euro",
+			},
 			"root_volume": {
 				Type:        schema.TypeList,
 				MaxItems:    1,
@@ -116,6 +151,12 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				Optional:    true,
 				Description: "The additional volumes attached to the server",
 			},
+			"external_volumes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "The additional volumes not listed in additional_volume_ids are managed outside of this resource",
+			},
 			"enable_ipv6": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -137,6 +178,14 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				Optional:         true,
 				Description:      "The ID of the reserved IP for the server",
 				DiffSuppressFunc: diffSuppressFuncLocality,
+				ConflictsWith:    []string{"external_ip"},
+			},
+			"external_ip": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				Description:   "The reserved IP of the server is managed outside of this resource",
+				ConflictsWith: []string{"ip_id"},
 			},
 			"ipv6_address": {
 				Type:        schema.TypeString,
@@ -189,15 +238,31 @@ func resourceScalewayInstanceServer() *schema.Resource {
 				ValidateFunc: validationUUID(),
 			},
 			"cloud_init": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The cloud init script associated with this server",
-				ValidateFunc: validation.StringLenBetween(0, 127998),
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The cloud init script associated with this server",
+				ValidateFunc:     validation.StringLenBetween(0, 127998),
+				DiffSuppressFunc: diffSuppressFuncUserData,
 			},
 			"user_data": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Description:      "The user data associated with the server", // TODO: document reserved keys (`cloud-init`)
+				DiffSuppressFunc: diffSuppressFuncUserData,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"reboot_on_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reboot the server when boot_type, bootscript_id or the cloud init script change",
+			},
+			"triggers": {
 				Type:        schema.TypeMap,
 				Optional:    true,
-				Description: "The user data associated with the server", // TODO: document reserved keys (`cloud-init`)
+				Description: "Arbitrary values that trigger a reboot of the server when they change",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -223,12 +288,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 
 	imageUUID := expandZonedID(d.Get("image")).ID
 	if !scwvalidation.IsUUID(imageUUID) {
-		marketPlaceAPI := marketplace.NewAPI(meta.(*Meta).scwClient)
-		imageUUID, err = marketPlaceAPI.GetLocalImageIDByLabel(&marketplace.GetLocalImageIDByLabelRequest{
-			CommercialType: commercialType,
-			Zone:           zone,
-			ImageLabel:     imageUUID,
-		})
+		imageUUID, err = getLocalImageIDByLabel(ctx, meta, zone, commercialType, imageUUID)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("could not get image '%s': %s", newZonedID(zone, imageUUID), err))
 		}
@@ -263,7 +323,7 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 		req.PlacementGroup = expandStringPtr(expandZonedID(placementGroupID).ID)
 	}
 
-	serverType := getServerType(instanceAPI, req.Zone, req.CommercialType)
+	serverType := getServerType(ctx, meta, req.Zone, req.CommercialType)
 	if serverType == nil {
 		return diag.FromErr(fmt.Errorf("could not find a server type associated with %s", req.CommercialType))
 	}
@@ -319,25 +379,12 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 	////
 	// Set user data
 	////
-	userDataRequests := &instance.SetAllServerUserDataRequest{
-		Zone:     zone,
-		ServerID: res.Server.ID,
-		UserData: make(map[string]io.Reader),
-	}
-
-	if rawUserData, ok := d.GetOk("user_data"); ok {
-		for key, value := range rawUserData.(map[string]interface{}) {
-			userDataRequests.UserData[key] = bytes.NewBufferString(value.(string))
-		}
-	}
-
-	// cloud init script is set in user data
-	if cloudInit, ok := d.GetOk("cloud_init"); ok {
-		userDataRequests.UserData["cloud-init"] = bytes.NewBufferString(cloudInit.(string))
-	}
-
-	if len(userDataRequests.UserData) > 0 {
-		err = instanceAPI.SetAllServerUserData(userDataRequests)
+	if userData := expandServerUserData(d); len(userData) > 0 {
+		err = instanceAPI.SetAllServerUserData(&instance.SetAllServerUserDataRequest{
+			Zone:     zone,
+			ServerID: res.Server.ID,
+			UserData: userData,
+		})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -352,6 +399,13 @@ func resourceScalewayInstanceServerCreate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
+	if req.PlacementGroup != nil {
+		err = enforceInstanceServerPlacementGroupPolicy(ctx, instanceAPI, zone, res.Server.ID, *req.PlacementGroup, d.Get("placement_group_enforce_policy").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceScalewayInstanceServerRead(ctx, d, meta)
 }
 
@@ -383,6 +437,13 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	_ = d.Set("state", state)
 	_ = d.Set("zone", string(zone))
 	_ = d.Set("name", response.Server.Name)
+
+	price, err := getInstanceServerTypeMonthlyPrice(ctx, meta, zone, response.Server.CommercialType)
+	if err != nil {
+		l.Warningf("cannot estimate the monthly cost of the server: %s", err)
+	} else {
+		_ = d.Set("estimated_monthly_cost", price)
+	}
 	_ = d.Set("boot_type", response.Server.BootType)
 	_ = d.Set("bootscript_id", response.Server.Bootscript.ID)
 	_ = d.Set("type", response.Server.CommercialType)
@@ -393,17 +454,51 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	_ = d.Set("organization_id", response.Server.Organization)
 	_ = d.Set("project_id", response.Server.Project)
 
+	var diags diag.Diagnostics
+
 	// Image could be empty in an import context.
 	image := expandRegionalID(d.Get("image").(string))
-	if response.Server.Image != nil && (image.ID == "" || scwvalidation.IsUUID(image.ID)) {
-		// TODO: If image is a label, check that response.Server.Image.ID match the label.
-		// It could be useful if the user edit the image with another tool.
-		_ = d.Set("image", newZonedID(zone, response.Server.Image.ID).String())
+	if response.Server.Image != nil {
+		_ = d.Set("image_id", newZonedID(zone, response.Server.Image.ID).String())
+		if image.ID == "" || scwvalidation.IsUUID(image.ID) {
+			_ = d.Set("image", newZonedID(zone, response.Server.Image.ID).String())
+		} else if d.Get("image_update_policy").(string) == instanceImageUpdatePolicyWarn {
+			// Only the warn policy resolves the image label on refresh, the replace policy resolves it at plan time.
+			latestImageID, err := getLocalImageIDByLabel(ctx, meta, zone, response.Server.CommercialType, image.ID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if latestImageID != response.Server.Image.ID {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("image %s now points to a newer image", image.ID),
+					Detail:   fmt.Sprintf("server %s was created from image %s, image %s is now %s", response.Server.Name, response.Server.Image.ID, image.ID, latestImageID),
+				})
+			}
+		}
+	} else {
+		_ = d.Set("image_id", "")
 	}
 
 	if response.Server.PlacementGroup != nil {
 		_ = d.Set("placement_group_id", newZonedID(zone, response.Server.PlacementGroup.ID).String())
 		_ = d.Set("placement_group_policy_respected", response.Server.PlacementGroup.PolicyRespected)
+
+		if isPlacementGroupPolicyEnforced(d.Get("placement_group_enforce_policy").(string)) {
+			servers, err := listPlacementGroupServers(ctx, instanceAPI, zone, response.Server.PlacementGroup.ID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			diags = append(diags, placementGroupServersDiagnostics(response.Server.PlacementGroup, servers)...)
+			if !response.Server.PlacementGroup.PolicyRespected {
+				// With the retry enforce policy, the policy is enforced by the next apply, see customizeDiffInstanceServerPlacementGroup.
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("placement group %s policy is not respected", response.Server.PlacementGroup.Name),
+					Detail:   fmt.Sprintf("placement group %s policy is not respected for server %s", response.Server.PlacementGroup.ID, response.Server.Name),
+				})
+			}
+		}
 	}
 
 	if response.Server.PrivateIP != nil {
@@ -416,7 +511,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 			"type": "ssh",
 			"host": response.Server.PublicIP.Address.String(),
 		})
-		if !response.Server.PublicIP.Dynamic {
+		if !response.Server.PublicIP.Dynamic && !d.Get("external_ip").(bool) {
 			_ = d.Set("ip_id", newZonedID(zone, response.Server.PublicIP.ID).String())
 		} else {
 			_ = d.Set("ip_id", "")
@@ -441,6 +536,14 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 		_ = d.Set("ipv6_prefix_length", nil)
 	}
 
+	// With external_volumes, only the volumes declared in additional_volume_ids are read back, so that the volumes
+	// attached by other means, e.g. scaleway_instance_volume_attachment, are not detached.
+	externalVolumes := d.Get("external_volumes").(bool)
+	declaredVolumeIDs := map[string]bool{}
+	for _, volumeID := range d.Get("additional_volume_ids").([]interface{}) {
+		declaredVolumeIDs[expandZonedID(volumeID).ID] = true
+	}
+
 	var additionalVolumesIDs []string
 	for i, volume := range orderVolumes(response.Server.Volumes) {
 		if i == 0 {
@@ -457,7 +560,7 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 			rootVolume["delete_on_termination"] = d.Get("root_volume.0.delete_on_termination").(bool) || !rootVolumeAttributeSet
 
 			_ = d.Set("root_volume", []map[string]interface{}{rootVolume})
-		} else {
+		} else if !externalVolumes || declaredVolumeIDs[volume.ID] {
 			additionalVolumesIDs = append(additionalVolumesIDs, newZonedID(zone, volume.ID).String())
 		}
 	}
@@ -466,28 +569,35 @@ func resourceScalewayInstanceServerRead(ctx context.Context, d *schema.ResourceD
 	////
 	// Read server user data
 	////
-	allUserData, _ := instanceAPI.GetAllServerUserData(&instance.GetAllServerUserDataRequest{
+	allUserData, err := instanceAPI.GetAllServerUserData(&instance.GetAllServerUserDataRequest{
 		Zone:     zone,
 		ServerID: ID,
 	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The cloud-init script is stored in user data under the cloud-init key.
+	// It is read back in user_data when it was configured there, in cloud_init otherwise.
+	_, cloudInitInUserData := d.Get("user_data").(map[string]interface{})[instanceUserDataKeyCloudInit]
 
 	userData := make(map[string]interface{})
+	cloudInit := ""
 	for key, value := range allUserData.UserData {
 		userDataValue, err := ioutil.ReadAll(value)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		//if key != "cloud-init" {
+		if key == instanceUserDataKeyCloudInit && !cloudInitInUserData {
+			cloudInit = string(userDataValue)
+			continue
+		}
 		userData[key] = string(userDataValue)
-		//	} else {
-		//_ = d.Set("cloud_init", string(userDataValue))
-		//}
-	}
-	if len(userData) > 0 {
-		_ = d.Set("user_data", userData)
 	}
+	_ = d.Set("user_data", userData)
+	_ = d.Set("cloud_init", cloudInit)
 
-	return nil
+	return diags
 }
 
 func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -534,13 +644,14 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 
 	volumes := map[string]*instance.VolumeTemplate{}
 
-	if raw, ok := d.GetOk("additional_volume_ids"); d.HasChange("additional_volume_ids") && ok {
+	if d.HasChange("additional_volume_ids") {
 		volumes["0"] = &instance.VolumeTemplate{
 			ID:   expandZonedID(d.Get("root_volume.0.volume_id")).ID,
 			Name: newRandomName("vol"), // name is ignored by the API, any name will work here
 		}
 
-		for i, volumeID := range raw.([]interface{}) {
+		oldVolumeIDs, newVolumeIDs := d.GetChange("additional_volume_ids")
+		for i, volumeID := range newVolumeIDs.([]interface{}) {
 			volumeHasChange := d.HasChange("additional_volume_ids." + strconv.Itoa(i))
 			// local volumes can only be added when the instance is stopped
 			if volumeHasChange && !isStopped {
@@ -565,6 +676,30 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 			}
 		}
 
+		// With external_volumes, the volumes attached by other means than additional_volume_ids are kept attached.
+		if d.Get("external_volumes").(bool) {
+			server, err := instanceAPI.GetServer(&instance.GetServerRequest{
+				Zone:     zone,
+				ServerID: ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			declaredVolumeIDs := map[string]bool{}
+			for _, volumeID := range append(oldVolumeIDs.([]interface{}), newVolumeIDs.([]interface{})...) {
+				declaredVolumeIDs[expandZonedID(volumeID).ID] = true
+			}
+			for i, volume := range orderVolumes(server.Server.Volumes) {
+				if i == 0 || declaredVolumeIDs[volume.ID] {
+					continue
+				}
+				volumes[strconv.Itoa(len(volumes))] = &instance.VolumeTemplate{
+					ID:   volume.ID,
+					Name: newRandomName("vol"), // name is ignored by the API, any name will work here
+				}
+			}
+		}
+
 		updateRequest.Volumes = &volumes
 	}
 
@@ -583,7 +718,7 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 	////
 	// Update reserved IP
 	////
-	if d.HasChange("ip_id") {
+	if d.HasChange("ip_id") && !d.Get("external_ip").(bool) {
 		server, err := instanceAPI.GetServer(&instance.GetServerRequest{
 			Zone:     zone,
 			ServerID: ID,
@@ -618,10 +753,19 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		}
 	}
 
+	// A reboot is only performed on a server that was already running: a server started
+	// by this update boots with the new configuration anyway.
+	previousState, _ := d.GetChange("state")
+	canReboot := wantedState == InstanceServerStateStarted && previousState.(string) == InstanceServerStateStarted
+	rebootOnChange := d.Get("reboot_on_change").(bool)
+	needReboot := canReboot && d.HasChange("triggers")
+
 	if d.HasChanges("boot_type") {
 		bootType := instance.BootType(d.Get("boot_type").(string))
 		updateRequest.BootType = &bootType
-		if !isStopped {
+		if rebootOnChange {
+			needReboot = needReboot || canReboot
+		} else if !isStopped {
 			warnings = append(warnings, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  "instance may need to be rebooted to use the new boot type",
@@ -631,7 +775,9 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 
 	if d.HasChanges("bootscript_id") {
 		updateRequest.Bootscript = expandStringPtr(d.Get("bootscript_id").(string))
-		if !isStopped {
+		if rebootOnChange {
+			needReboot = needReboot || canReboot
+		} else if !isStopped {
 			warnings = append(warnings, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  "instance may need to be rebooted to use the new bootscript",
@@ -642,19 +788,11 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 	////
 	// Update server user data
 	////
-	if d.HasChanges("user_data") {
-		userDataRequests := &instance.SetAllServerUserDataRequest{
-			Zone:     zone,
-			ServerID: ID,
-			UserData: make(map[string]io.Reader),
-		}
-
-		if allUserData, ok := d.GetOk("user_data"); ok {
-			userDataMap := allUserData.(map[string]interface{})
-			for key, value := range userDataMap {
-				userDataRequests.UserData[key] = bytes.NewBufferString(value.(string))
-			}
-			if !isStopped && d.HasChange("user_data.cloud-init") {
+	if d.HasChanges("user_data", "cloud_init") {
+		if d.HasChanges("user_data."+instanceUserDataKeyCloudInit, "cloud_init") {
+			if rebootOnChange {
+				needReboot = needReboot || canReboot
+			} else if !isStopped {
 				warnings = append(warnings, diag.Diagnostic{
 					Severity: diag.Warning,
 					Summary:  "instance may need to be rebooted to use the new cloud init config",
@@ -662,7 +800,11 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 			}
 		}
 
-		err := instanceAPI.SetAllServerUserData(userDataRequests)
+		err := instanceAPI.SetAllServerUserData(&instance.SetAllServerUserDataRequest{
+			Zone:     zone,
+			ServerID: ID,
+			UserData: expandServerUserData(d),
+		})
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -688,6 +830,28 @@ func resourceScalewayInstanceServerUpdate(ctx context.Context, d *schema.Resourc
 		return diag.FromErr(err)
 	}
 
+	if placementGroupID := expandZonedID(d.Get("placement_group_id")).ID; placementGroupID != "" {
+		err = enforceInstanceServerPlacementGroupPolicy(ctx, instanceAPI, zone, ID, placementGroupID, d.Get("placement_group_enforce_policy").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	////
+	// Reboot the server
+	////
+	if needReboot {
+		err = instanceAPI.ServerActionAndWait(&instance.ServerActionAndWaitRequest{
+			Zone:     zone,
+			ServerID: ID,
+			Action:   instance.ServerActionReboot,
+			Timeout:  scw.TimeDurationPtr(d.Timeout(schema.TimeoutUpdate)),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return append(warnings, resourceScalewayInstanceServerRead(ctx, d, meta)...)
 }
 
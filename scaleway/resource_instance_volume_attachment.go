@@ -0,0 +1,146 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayInstanceVolumeAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayInstanceVolumeAttachmentCreate,
+		ReadContext:   resourceScalewayInstanceVolumeAttachmentRead,
+		DeleteContext: resourceScalewayInstanceVolumeAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultInstanceServerWaitTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "The server the volume is attached to",
+			},
+			"volume_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "The volume to attach to the server",
+			},
+			"zone": zoneSchema(),
+		},
+	}
+}
+
+func resourceScalewayInstanceVolumeAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, err := instanceAPIWithZone(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := expandZonedID(d.Get("server_id")).ID
+	volumeID := expandZonedID(d.Get("volume_id")).ID
+
+	volume, err := instanceAPI.GetVolume(&instance.GetVolumeRequest{
+		Zone:     zone,
+		VolumeID: volumeID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if volume.Volume.Server != nil {
+		return diag.FromErr(fmt.Errorf("volume %s is already attached to server %s", volumeID, volume.Volume.Server.ID))
+	}
+
+	err = withServerStoppedForLocalVolume(ctx, instanceAPI, zone, serverID, volume.Volume, func() error {
+		_, err := instanceAPI.AttachVolume(&instance.AttachVolumeRequest{
+			Zone:     zone,
+			ServerID: serverID,
+			VolumeID: volumeID,
+		}, scw.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newZonedNestedIDString(zone, serverID, volumeID))
+
+	return resourceScalewayInstanceVolumeAttachmentRead(ctx, d, meta)
+}
+
+func resourceScalewayInstanceVolumeAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, volumeID, serverID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volume, err := instanceAPI.GetVolume(&instance.GetVolumeRequest{
+		Zone:     zone,
+		VolumeID: volumeID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// The attachment is gone when the volume was detached or attached to another server.
+	if volume.Volume.Server == nil || volume.Volume.Server.ID != serverID {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("server_id", newZonedIDString(zone, serverID))
+	_ = d.Set("volume_id", newZonedIDString(zone, volumeID))
+	_ = d.Set("zone", zone)
+
+	return nil
+}
+
+func resourceScalewayInstanceVolumeAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	instanceAPI, zone, volumeID, serverID, err := instanceAPIWithZoneAndNestedID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	volume, err := instanceAPI.GetVolume(&instance.GetVolumeRequest{
+		Zone:     zone,
+		VolumeID: volumeID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if volume.Volume.Server == nil || volume.Volume.Server.ID != serverID {
+		return nil
+	}
+
+	err = withServerStoppedForLocalVolume(ctx, instanceAPI, zone, serverID, volume.Volume, func() error {
+		_, err := instanceAPI.DetachVolume(&instance.DetachVolumeRequest{
+			Zone:     zone,
+			VolumeID: volumeID,
+		}, scw.WithContext(ctx))
+		return err
+	})
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
@@ -26,6 +27,13 @@ func resourceScalewayK8SCluster() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultK8SClusterTimeout),
 		},
 		SchemaVersion: 0,
+		CustomizeDiff: customdiff.All(
+			customizeDiffK8SClusterVersionCapabilities,
+			customizeDiffK8SClusterVersionSkew,
+			customdiff.ComputedIf("kubeconfig", func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) bool {
+				return diff.Id() != "" && diff.HasChange("rotate_admin_token")
+			}),
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -43,6 +51,20 @@ func resourceScalewayK8SCluster() *schema.Resource {
 				Required:    true,
 				Description: "The version of the cluster",
 			},
+			"upgrade_pools": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Upgrade the pools one after the other once the control plane is upgraded",
+			},
+			"upgrade_pools_exclude": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The names of the pools not upgraded with the cluster, e.g. the pools setting their version",
+			},
 			"cni": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -108,6 +130,24 @@ func resourceScalewayK8SCluster() *schema.Resource {
 					},
 				},
 			},
+			"ingress": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The ingress controller to deploy on the cluster",
+				ValidateFunc: validation.StringInSlice([]string{
+					k8s.IngressNone.String(),
+					k8s.IngressNginx.String(),
+					k8s.IngressTraefik.String(),
+					k8s.IngressTraefik2.String(),
+				}, false),
+			},
+			"enable_dashboard": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Deploy the Kubernetes dashboard on the cluster",
+			},
 			"feature_gates": {
 				Type: schema.TypeList,
 				Elem: &schema.Schema{
@@ -170,6 +210,11 @@ func resourceScalewayK8SCluster() *schema.Resource {
 				Computed:    true,
 				Description: "Wildcard DNS pointing to all the ready nodes",
 			},
+			"rotate_admin_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Any change of this value resets the admin token of the cluster",
+			},
 			"kubeconfig": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -240,6 +285,14 @@ func resourceScalewayK8SClusterCreate(ctx context.Context, d *schema.ResourceDat
 		ApiserverCertSans: expandStrings(d.Get("apiserver_cert_sans")),
 	}
 
+	if ingress, ok := d.GetOk("ingress"); ok {
+		req.Ingress = k8s.Ingress(ingress.(string))
+	}
+
+	if enableDashboard, ok := d.GetOk("enable_dashboard"); ok {
+		req.EnableDashboard = enableDashboard.(bool)
+	}
+
 	autoscalerReq := &k8s.CreateClusterRequestAutoscalerConfig{}
 
 	if scaleDownDisabled, ok := d.GetOk("autoscaler_config.0.disable_scale_down"); ok {
@@ -410,6 +463,8 @@ func resourceScalewayK8SClusterRead(ctx context.Context, d *schema.ResourceData,
 	_ = d.Set("wildcard_dns", response.DNSWildcard)
 	_ = d.Set("status", response.Status.String())
 	_ = d.Set("upgrade_available", response.UpgradeAvailable)
+	_ = d.Set("ingress", response.Ingress)
+	_ = d.Set("enable_dashboard", response.DashboardEnabled)
 	_ = d.Set("feature_gates", response.FeatureGates)
 	_ = d.Set("admission_plugins", response.AdmissionPlugins)
 
@@ -472,6 +527,7 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 	}
 
 	canUpgrade := false
+	previousVersion := ""
 
 	////
 	// Construct UpdateClusterRequest
@@ -498,6 +554,14 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 		updateRequest.ApiserverCertSans = scw.StringsPtr(expandStrings(d.Get("apiserver_cert_sans")))
 	}
 
+	if d.HasChange("ingress") {
+		updateRequest.Ingress = k8s.Ingress(d.Get("ingress").(string))
+	}
+
+	if d.HasChange("enable_dashboard") {
+		updateRequest.EnableDashboard = scw.BoolPtr(d.Get("enable_dashboard").(bool))
+	}
+
 	if d.HasChange("feature_gates") {
 		updateRequest.FeatureGates = scw.StringsPtr(expandStrings(d.Get("feature_gates")))
 	}
@@ -545,6 +609,7 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 			return diag.FromErr(err)
 		}
 
+		previousVersion = clusterResp.Version
 		if clusterResp.Version == version {
 			// no upgrades if same version
 			canUpgrade = false
@@ -647,11 +712,12 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 	// Upgrade if needed
 	////
 	if canUpgrade {
+		// Pools are upgraded by the provider once the control plane is upgraded, not by the API.
 		upgradeRequest := &k8s.UpgradeClusterRequest{
 			Region:       region,
 			ClusterID:    clusterID,
 			Version:      version,
-			UpgradePools: true,
+			UpgradePools: false,
 		}
 		_, err = k8sAPI.UpgradeCluster(upgradeRequest)
 		if err != nil {
@@ -662,6 +728,23 @@ func resourceScalewayK8SClusterUpdate(ctx context.Context, d *schema.ResourceDat
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		if d.Get("upgrade_pools").(bool) {
+			err = k8sUpgradePools(ctx, k8sAPI, region, clusterID, previousVersion, version, expandStrings(d.Get("upgrade_pools_exclude")))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.HasChange("rotate_admin_token") {
+		err = k8sAPI.ResetClusterAdminToken(&k8s.ResetClusterAdminTokenRequest{
+			Region:    region,
+			ClusterID: clusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceScalewayK8SClusterRead(ctx, d, meta)
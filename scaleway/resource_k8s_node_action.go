@@ -0,0 +1,169 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayK8SNodeAction() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayK8SNodeActionCreate,
+		ReadContext:   resourceScalewayK8SNodeActionRead,
+		DeleteContext: resourceScalewayK8SNodeActionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultK8SPoolTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"pool_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the pool of the nodes",
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The action to apply on the nodes",
+				ValidateFunc: validation.StringInSlice([]string{
+					k8sNodeActionReboot,
+					k8sNodeActionReplace,
+				}, false),
+			},
+			"node_names": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Apply the action only on the nodes with these names",
+			},
+			"node_status": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Apply the action only on the nodes with this status",
+				ValidateFunc: validation.StringInSlice([]string{
+					k8s.NodeStatusCreating.String(),
+					k8s.NodeStatusNotReady.String(),
+					k8s.NodeStatusReady.String(),
+					k8s.NodeStatusLocked.String(),
+					k8s.NodeStatusRebooting.String(),
+					k8s.NodeStatusCreationError.String(),
+					k8s.NodeStatusUpgrading.String(),
+				}, false),
+			},
+			"triggers": {
+				Type: schema.TypeMap,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Any change of these values applies the action again",
+			},
+			"region": regionSchema(),
+			// Computed elements
+			"nodes": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The names of the nodes the action was applied on",
+			},
+		},
+	}
+}
+
+func resourceScalewayK8SNodeActionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The region of the pool ID takes precedence over the region of the resource.
+	poolID := expandRegionalID(d.Get("pool_id"))
+	if poolID.Region != "" {
+		region = poolID.Region
+	}
+
+	pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: poolID.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := k8sAPI.ListNodes(&k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: pool.ClusterID,
+		PoolID:    &pool.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodes, err := filterK8SNodes(res.Nodes, expandStrings(d.Get("node_names")), d.Get("node_status").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeNames := []string(nil)
+	for _, node := range nodes {
+		nodeNames = append(nodeNames, node.Name)
+	}
+
+	err = k8sApplyNodeAction(ctx, k8sAPI, region, pool.ID, d.Get("action").(string), nodes)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Several actions can be applied on the same pool, so the ID of each action is made unique with its time.
+	d.SetId(newRegionalIDString(region, fmt.Sprintf("%s-%d", pool.ID, time.Now().UnixNano())))
+	_ = d.Set("region", region)
+	_ = d.Set("nodes", nodeNames)
+
+	return resourceScalewayK8SNodeActionRead(ctx, d, meta)
+}
+
+func resourceScalewayK8SNodeActionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	k8sAPI, region, err := k8sAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = k8sAPI.GetPool(&k8s.GetPoolRequest{
+		Region: region,
+		PoolID: expandRegionalID(d.Get("pool_id")).ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayK8SNodeActionDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// Applied actions cannot be undone, the resource is only removed from the state.
+	d.SetId("")
+
+	return nil
+}
@@ -2,9 +2,12 @@ package scaleway
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
@@ -24,6 +27,15 @@ func resourceScalewayK8SPool() *schema.Resource {
 			Default: schema.DefaultTimeout(defaultK8SPoolTimeout),
 		},
 		SchemaVersion: 0,
+		CustomizeDiff: customdiff.All(
+			customizeDiffK8SPoolZones,
+			customizeDiffK8SPoolMissingPools,
+			customizeDiffK8SPoolReplaceStrategy,
+			customizeDiffK8SPoolVersionCapabilities,
+			customizeDiffK8SPoolVersion,
+			customizeDiffK8SPoolEstimatedMonthlyCost,
+			customizeDiffK8SPoolPreviousPool,
+		),
 		Schema: map[string]*schema.Schema{
 			"cluster_id": {
 				Type:        schema.TypeString,
@@ -33,13 +45,11 @@ func resourceScalewayK8SPool() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 				Description: "The name of the cluster",
 			},
 			"node_type": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				Description:      "Server type of the pool servers",
 				DiffSuppressFunc: diffSuppressFuncIgnoreCaseAndHyphen,
 			},
@@ -98,12 +108,31 @@ func resourceScalewayK8SPool() *schema.Resource {
 				Default:     false,
 				Description: "Whether to wait for the pool to be ready",
 			},
-			"placement_group_id": {
+			"replace_strategy": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
-				Default:     nil,
-				Description: "ID of the placement group",
+				Default:     k8sPoolReplaceStrategyRecreate,
+				Description: "The strategy used to replace the pool when its name or node type changes",
+				ValidateFunc: validation.StringInSlice([]string{
+					k8sPoolReplaceStrategyRecreate,
+					k8sPoolReplaceStrategyBlueGreen,
+				}, false),
+			},
+			"replace_grace_period": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "0s",
+				DiffSuppressFunc: diffSuppressFuncDuration,
+				ValidateFunc:     validateDuration(),
+				Description:      "The time to wait between the readiness of the new pool and the deletion of the replaced pool",
+			},
+			"placement_group_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Default:       nil,
+				Description:   "ID of the placement group",
+				ConflictsWith: []string{"zones"},
 			},
 			"kubelet_args": {
 				Type: schema.TypeMap,
@@ -136,7 +165,27 @@ func resourceScalewayK8SPool() *schema.Resource {
 					},
 				},
 			},
-			"zone":   zoneSchema(),
+			"zone": {
+				Type:          schema.TypeString,
+				Description:   "The zone you want to attach the resource to",
+				Optional:      true,
+				ForceNew:      true,
+				Computed:      true,
+				ValidateFunc:  zoneSchema().ValidateFunc,
+				ConflictsWith: []string{"zones"},
+			},
+			"zones": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: zoneSchema().ValidateFunc,
+				},
+				Optional:      true,
+				ForceNew:      true,
+				MinItems:      2,
+				Description:   "Spread the pool over these zones, with one pool per zone sharing the sizes evenly",
+				ConflictsWith: []string{"zone"},
+			},
 			"region": regionSchema(),
 			// Computed elements
 			"created_at": {
@@ -151,14 +200,33 @@ func resourceScalewayK8SPool() *schema.Resource {
 			},
 			"version": {
 				Type:        schema.TypeString,
+				Optional:    true,
 				Computed:    true,
 				Description: "The Kubernetes version of the pool",
 			},
+			"pool_ids": {
+				Type: schema.TypeList,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Computed:    true,
+				Description: "The IDs of the pools created in each of the zones",
+			},
+			"previous_pool_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the pool replaced by a blue_green replacement that could not be deleted yet",
+			},
 			"current_size": {
 				Type:        schema.TypeInt,
 				Computed:    true,
 				Description: "The actual size of the pool",
 			},
+			"estimated_monthly_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The estimated monthly cost of the pool nodes in Euro",
+			},
 			"nodes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -184,6 +252,11 @@ func resourceScalewayK8SPool() *schema.Resource {
 							Computed:    true,
 							Description: "The public IPv6 address of the node",
 						},
+						"zone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The zone of the node",
+						},
 					},
 				},
 			},
@@ -206,48 +279,7 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 	// Create pool
 	////
 
-	req := &k8s.CreatePoolRequest{
-		Region:      region,
-		ClusterID:   expandID(d.Get("cluster_id")),
-		Name:        expandOrGenerateString(d.Get("name"), "pool"),
-		NodeType:    d.Get("node_type").(string),
-		Autoscaling: d.Get("autoscaling").(bool),
-		Autohealing: d.Get("autohealing").(bool),
-		Size:        uint32(d.Get("size").(int)),
-		Tags:        expandStrings(d.Get("tags")),
-		Zone:        scw.Zone(d.Get("zone").(string)),
-		KubeletArgs: expandKubeletArgs(d.Get("kubelet_args")),
-	}
-
-	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
-		req.PlacementGroupID = expandStringPtr(expandID(placementGroupID))
-	}
-
-	if minSize, ok := d.GetOk("min_size"); ok {
-		req.MinSize = scw.Uint32Ptr(uint32(minSize.(int)))
-	}
-
-	if maxSize, ok := d.GetOk("max_size"); ok {
-		req.MaxSize = scw.Uint32Ptr(uint32(maxSize.(int)))
-	} else {
-		req.MaxSize = scw.Uint32Ptr(req.Size)
-	}
-
-	if containerRuntime, ok := d.GetOk("container_runtime"); ok {
-		req.ContainerRuntime = k8s.Runtime(containerRuntime.(string))
-	}
-
-	upgradePolicyReq := &k8s.CreatePoolRequestUpgradePolicy{}
-
-	if maxSurge, ok := d.GetOk("upgrade_policy.0.max_surge"); ok {
-		req.UpgradePolicy = upgradePolicyReq
-		upgradePolicyReq.MaxSurge = scw.Uint32Ptr(uint32(maxSurge.(int)))
-	}
-
-	if maxUnavailable, ok := d.GetOk("upgrade_policy.0.max_unavailable"); ok {
-		req.UpgradePolicy = upgradePolicyReq
-		upgradePolicyReq.MaxUnavailable = scw.Uint32Ptr(uint32(maxUnavailable.(int)))
-	}
+	req := expandK8SPoolCreateRequest(d, region)
 
 	// check if the cluster is waiting for a pool
 	cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
@@ -258,6 +290,13 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if version, ok := d.GetOk("version"); ok {
+		err = k8sCheckPoolVersion(cluster, version.(string), true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	waitForCluster := false
 
 	if cluster.Status == k8s.ClusterStatusPoolRequired {
@@ -269,24 +308,42 @@ func resourceScalewayK8SPoolCreate(ctx context.Context, d *schema.ResourceData,
 		}
 	}
 
-	res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	reqs := []*k8s.CreatePoolRequest{req}
+	if zones, ok := d.GetOk("zones"); ok {
+		reqs = k8sSplitPoolCreateRequest(req, expandStrings(zones))
 	}
 
-	d.SetId(newRegionalIDString(region, res.ID))
-
-	if waitForCluster {
-		err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, k8s.ClusterStatusReady)
+	poolIDs := []string(nil)
+	for _, req := range reqs {
+		res, err := k8sAPI.CreatePool(req, scw.WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		// Keep track of the pools already created if the next ones fail.
+		if len(poolIDs) == 0 {
+			d.SetId(newRegionalIDString(region, res.ID))
+		}
+		if len(reqs) > 1 {
+			poolIDs = append(poolIDs, newRegionalIDString(region, res.ID))
+			_ = d.Set("pool_ids", poolIDs)
+		}
+
+		if waitForCluster {
+			err = waitK8SCluster(ctx, k8sAPI, region, cluster.ID, k8s.ClusterStatusReady)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			waitForCluster = false
+		}
 	}
 
 	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID)
-		if err != nil {
-			return diag.FromErr(err)
+		for _, poolID := range k8sPoolIDs(d) {
+			err = waitK8SPoolReady(ctx, k8sAPI, region, poolID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
 		}
 	}
 
@@ -302,43 +359,94 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 	////
 	// Read Pool
 	////
-	pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
-		Region: region,
-		PoolID: poolID,
-	}, scw.WithContext(ctx))
-	if err != nil {
-		if is404Error(err) {
-			d.SetId("")
-			return nil
+	// A pool spread over zones is made of one pool per zone: sizes are summed up,
+	// and the other attributes, shared by all the pools, are read from the first one.
+	pools := []*k8s.Pool(nil)
+	missingPoolIDs := []string(nil)
+	for _, poolID := range k8sPoolIDs(d) {
+		pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
+			Region: region,
+			PoolID: poolID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if is404Error(err) {
+				missingPoolIDs = append(missingPoolIDs, poolID)
+				continue
+			}
+			return diag.FromErr(err)
 		}
-		return diag.FromErr(err)
-	}
-
-	nodes, err := getNodes(ctx, k8sAPI, pool)
-	if err != nil {
-		return diag.FromErr(err)
+		pools = append(pools, pool)
+	}
+	if len(pools) == 0 {
+		d.SetId("")
+		return nil
+	}
+	var diags diag.Diagnostics
+	if len(missingPoolIDs) > 0 {
+		// The missing pools are dropped from pool_ids, so that the pool is planned to be recreated,
+		// see customizeDiffK8SPoolMissingPools.
+		poolIDs := []string(nil)
+		for _, zonePool := range pools {
+			poolIDs = append(poolIDs, newRegionalIDString(region, zonePool.ID))
+		}
+		_ = d.Set("pool_ids", poolIDs)
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("pools %s of pool %s were deleted outside of terraform", strings.Join(missingPoolIDs, ", "), poolID),
+			Detail:   "the pool is recreated by the next apply",
+		})
+	}
+
+	pool := pools[0]
+	nodes := []map[string]interface{}(nil)
+	status := pool.Status
+	var size, minSize, maxSize uint32
+	zones := []scw.Zone(nil)
+	for _, zonePool := range pools {
+		zoneNodes, err := getNodes(ctx, k8sAPI, zonePool)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		nodes = append(nodes, zoneNodes...)
+		if zonePool.Status != k8s.PoolStatusReady {
+			status = zonePool.Status
+		}
+		zones = append(zones, zonePool.Zone)
+		size += zonePool.Size
+		minSize += zonePool.MinSize
+		maxSize += zonePool.MaxSize
 	}
 
 	_ = d.Set("cluster_id", newRegionalIDString(region, pool.ClusterID))
-	_ = d.Set("name", pool.Name)
+	if len(pools) == 1 {
+		// The name of the pools spread over zones is suffixed by their zone.
+		_ = d.Set("name", flattenK8SPoolName(d.Get("name").(string), pool.Name))
+	}
 	_ = d.Set("node_type", pool.NodeType)
 	_ = d.Set("autoscaling", pool.Autoscaling)
 	_ = d.Set("autohealing", pool.Autohealing)
-	_ = d.Set("current_size", int(pool.Size))
+	_ = d.Set("current_size", int(size))
 	if !pool.Autoscaling {
-		_ = d.Set("size", int(pool.Size))
+		_ = d.Set("size", int(size))
 	}
 	_ = d.Set("version", pool.Version)
-	_ = d.Set("min_size", int(pool.MinSize))
-	_ = d.Set("max_size", int(pool.MaxSize))
+	_ = d.Set("min_size", int(minSize))
+	_ = d.Set("max_size", int(maxSize))
 	_ = d.Set("tags", pool.Tags)
 	_ = d.Set("container_runtime", pool.ContainerRuntime)
 	_ = d.Set("created_at", pool.CreatedAt.Format(time.RFC3339))
 	_ = d.Set("updated_at", pool.UpdatedAt.Format(time.RFC3339))
 	_ = d.Set("nodes", nodes)
-	_ = d.Set("status", pool.Status)
+	_ = d.Set("status", status)
 	_ = d.Set("kubelet_args", flattenKubeletArgs(pool.KubeletArgs))
 	_ = d.Set("zone", pool.Zone)
+
+	cost, err := getK8SPoolEstimatedMonthlyCost(ctx, meta, zones, pool.NodeType, d.Get("size").(int))
+	if err != nil {
+		l.Warningf("cannot estimate the monthly cost of the pool: %s", err)
+	} else {
+		_ = d.Set("estimated_monthly_cost", cost)
+	}
 	_ = d.Set("upgrade_policy", poolUpgradePolicyFlatten(pool))
 
 	if pool.PlacementGroupID != nil {
@@ -346,7 +454,7 @@ func resourceScalewayK8SPoolRead(ctx context.Context, d *schema.ResourceData, me
 		_ = d.Set("placement_group_id", newZonedID(zone, *pool.PlacementGroupID).String())
 	}
 
-	return nil
+	return diags
 }
 
 func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -355,6 +463,27 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	// The pool replaced by a previous blue_green replacement is deleted before any other change.
+	if previousPoolID, _ := d.GetChange("previous_pool_id"); previousPoolID.(string) != "" {
+		// On error, keep the previous state so the deletion is planned again.
+		d.Partial(true)
+		err = deleteK8SPool(ctx, k8sAPI, region, expandID(previousPoolID))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Partial(false)
+		_ = d.Set("previous_pool_id", "")
+	}
+
+	if d.HasChanges("name", "node_type") {
+		// The recreate strategy forces a new resource so only the blue_green strategy gets here.
+		diags := resourceScalewayK8SPoolReplace(ctx, d, k8sAPI, region, poolID)
+		if diags.HasError() {
+			return diags
+		}
+		return append(diags, resourceScalewayK8SPoolRead(ctx, d, meta)...)
+	}
+
 	////
 	// Update Pool
 	////
@@ -404,23 +533,67 @@ func resourceScalewayK8SPoolUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updateRequest.UpgradePolicy = upgradePolicyReq
 
-	res, err := k8sAPI.UpdatePool(updateRequest, scw.WithContext(ctx))
-	if err != nil {
-		return diag.FromErr(err)
+	// The sizes are shared evenly by the pools spread over zones.
+	poolIDs := k8sPoolIDs(d)
+	sizes := k8sSplitPoolSize(uint32(d.Get("size").(int)), len(poolIDs))
+	minSizes := k8sSplitPoolSize(uint32(d.Get("min_size").(int)), len(poolIDs))
+	maxSizes := k8sSplitPoolSize(uint32(d.Get("max_size").(int)), len(poolIDs))
+
+	if d.HasChange("version") {
+		cluster, err := k8sAPI.GetCluster(&k8s.GetClusterRequest{
+			Region:    region,
+			ClusterID: expandID(d.Get("cluster_id")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		err = k8sCheckPoolVersion(cluster, d.Get("version").(string), false)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
-		err = waitK8SPoolReady(ctx, k8sAPI, region, res.ID)
+	for i, poolID := range poolIDs {
+		zoneUpdateRequest := *updateRequest
+		zoneUpdateRequest.PoolID = poolID
+		if updateRequest.Size != nil {
+			zoneUpdateRequest.Size = scw.Uint32Ptr(sizes[i])
+		}
+		if updateRequest.MinSize != nil {
+			zoneUpdateRequest.MinSize = scw.Uint32Ptr(minSizes[i])
+		}
+		if updateRequest.MaxSize != nil {
+			zoneUpdateRequest.MaxSize = scw.Uint32Ptr(maxSizes[i])
+		}
+
+		_, err = k8sAPI.UpdatePool(&zoneUpdateRequest, scw.WithContext(ctx))
 		if err != nil {
 			return diag.FromErr(err)
 		}
+
+		if d.HasChange("version") {
+			err = k8sUpgradePool(ctx, k8sAPI, region, poolID, d.Get("version").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	if d.Get("wait_for_pool_ready").(bool) { // wait for the pool to be ready if specified (including all its nodes)
+		for _, poolID := range poolIDs {
+			err = waitK8SPoolReady(ctx, k8sAPI, region, poolID)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
 	}
 
 	return resourceScalewayK8SPoolRead(ctx, d, meta)
 }
 
 func resourceScalewayK8SPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	k8sAPI, region, poolID, err := k8sAPIWithRegionAndID(meta, d.Id())
+	k8sAPI, region, _, err := k8sAPIWithRegionAndID(meta, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -428,15 +601,118 @@ func resourceScalewayK8SPoolDelete(ctx context.Context, d *schema.ResourceData,
 	////
 	// Delete Pool
 	////
-	_, err = k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+	poolIDs := k8sPoolIDs(d)
+	if previousPoolID := d.Get("previous_pool_id").(string); previousPoolID != "" {
+		poolIDs = append(poolIDs, expandID(previousPoolID))
+	}
+	for _, poolID := range poolIDs {
+		_, err = k8sAPI.DeletePool(&k8s.DeletePoolRequest{
+			Region: region,
+			PoolID: poolID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			if !is404Error(err) {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// k8sPoolIDs returns the IDs of the pools managed by the resource: the pool itself,
+// or one pool per zone when the pool is spread over zones.
+func k8sPoolIDs(d *schema.ResourceData) []string {
+	poolIDs := []string(nil)
+	for _, poolID := range expandStrings(d.Get("pool_ids")) {
+		poolIDs = append(poolIDs, expandID(poolID))
+	}
+	if len(poolIDs) == 0 {
+		poolIDs = append(poolIDs, expandID(d.Id()))
+	}
+
+	return poolIDs
+}
+
+// resourceScalewayK8SPoolReplace replaces the pool with a new pool built from the configuration.
+// Pool names are unique within a cluster, so a pool keeping its name is replaced by a pool with a suffixed name.
+func resourceScalewayK8SPoolReplace(ctx context.Context, d *schema.ResourceData, k8sAPI *k8s.API, region scw.Region, poolID string) diag.Diagnostics {
+	// On error, keep the previous state so the replacement is planned again.
+	d.Partial(true)
+
+	pool, err := k8sAPI.GetPool(&k8s.GetPoolRequest{
 		Region: region,
 		PoolID: poolID,
 	}, scw.WithContext(ctx))
 	if err != nil {
-		if !is404Error(err) {
-			return diag.FromErr(err)
-		}
+		return diag.FromErr(err)
 	}
 
-	return nil
+	req := expandK8SPoolCreateRequest(d, region)
+	req.Name = k8sPoolReplacementName(req.Name, pool.Name)
+	if currentSize := uint32(d.Get("current_size").(int)); req.Autoscaling && currentSize > req.Size {
+		req.Size = currentSize
+	}
+
+	newPool, diags := k8sReplacePool(ctx, k8sAPI, region, poolID, req, *expandDuration(d.Get("replace_grace_period")))
+	if newPool == nil {
+		return diags
+	}
+
+	// The new pool replaces the pool even when the replaced pool could not be deleted:
+	// the replaced pool is then tracked in previous_pool_id so that its deletion is retried by the next apply.
+	d.Partial(false)
+	d.SetId(newRegionalIDString(region, newPool.ID))
+	if diags.HasError() {
+		_ = d.Set("previous_pool_id", newRegionalIDString(region, poolID))
+	}
+
+	return diags
+}
+
+func expandK8SPoolCreateRequest(d *schema.ResourceData, region scw.Region) *k8s.CreatePoolRequest {
+	req := &k8s.CreatePoolRequest{
+		Region:      region,
+		ClusterID:   expandID(d.Get("cluster_id")),
+		Name:        expandOrGenerateString(d.Get("name"), "pool"),
+		NodeType:    d.Get("node_type").(string),
+		Autoscaling: d.Get("autoscaling").(bool),
+		Autohealing: d.Get("autohealing").(bool),
+		Size:        uint32(d.Get("size").(int)),
+		Tags:        expandStrings(d.Get("tags")),
+		Zone:        scw.Zone(d.Get("zone").(string)),
+		KubeletArgs: expandKubeletArgs(d.Get("kubelet_args")),
+	}
+
+	if placementGroupID, ok := d.GetOk("placement_group_id"); ok {
+		req.PlacementGroupID = expandStringPtr(expandID(placementGroupID))
+	}
+
+	if minSize, ok := d.GetOk("min_size"); ok {
+		req.MinSize = scw.Uint32Ptr(uint32(minSize.(int)))
+	}
+
+	if maxSize, ok := d.GetOk("max_size"); ok {
+		req.MaxSize = scw.Uint32Ptr(uint32(maxSize.(int)))
+	} else {
+		req.MaxSize = scw.Uint32Ptr(req.Size)
+	}
+
+	if containerRuntime, ok := d.GetOk("container_runtime"); ok {
+		req.ContainerRuntime = k8s.Runtime(containerRuntime.(string))
+	}
+
+	upgradePolicyReq := &k8s.CreatePoolRequestUpgradePolicy{}
+
+	if maxSurge, ok := d.GetOk("upgrade_policy.0.max_surge"); ok {
+		req.UpgradePolicy = upgradePolicyReq
+		upgradePolicyReq.MaxSurge = scw.Uint32Ptr(uint32(maxSurge.(int)))
+	}
+
+	if maxUnavailable, ok := d.GetOk("upgrade_policy.0.max_unavailable"); ok {
+		req.UpgradePolicy = upgradePolicyReq
+		upgradePolicyReq.MaxUnavailable = scw.Uint32Ptr(uint32(maxUnavailable.(int)))
+	}
+
+	return req
 }
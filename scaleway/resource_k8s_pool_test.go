@@ -1,12 +1,19 @@
 package scaleway
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccScalewayK8SCluster_PoolBasic(t *testing.T) {
@@ -482,3 +489,51 @@ resource "scaleway_k8s_cluster" "zone" {
 	tags = [ "terraform-test", "scaleway_k8s_cluster", "zone" ]
 }`, zone, version)
 }
+
+func TestK8SPoolReplaceDeleteFailure(t *testing.T) {
+	// The API creates the new pool but refuses to delete the replaced pool.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/k8s/v1/regions/fr-par/pools/11111111-1111-1111-1111-111111111111":
+			_, _ = fmt.Fprint(w, `{"id": "11111111-1111-1111-1111-111111111111", "name": "default", "node_type": "dev1_m", "status": "ready"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/k8s/v1/regions/fr-par/clusters/33333333-3333-3333-3333-333333333333/pools":
+			_, _ = fmt.Fprint(w, `{"id": "22222222-2222-2222-2222-222222222222", "name": "default-replacement", "node_type": "gp1_xs", "status": "scaling"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/k8s/v1/regions/fr-par/pools/22222222-2222-2222-2222-222222222222":
+			_, _ = fmt.Fprint(w, `{"id": "22222222-2222-2222-2222-222222222222", "name": "default-replacement", "node_type": "gp1_xs", "status": "ready"}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/k8s/v1/regions/fr-par/pools/11111111-1111-1111-1111-111111111111":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = fmt.Fprint(w, `{"message": "pool is locked", "type": "conflict"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+	)
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, resourceScalewayK8SPool().Schema, map[string]interface{}{
+		"cluster_id":       "fr-par/33333333-3333-3333-3333-333333333333",
+		"name":             "default",
+		"node_type":        "gp1_xs",
+		"size":             1,
+		"replace_strategy": k8sPoolReplaceStrategyBlueGreen,
+	})
+	d.SetId("fr-par/11111111-1111-1111-1111-111111111111")
+
+	diags := resourceScalewayK8SPoolReplace(context.Background(), d, k8s.NewAPI(client), scw.RegionFrPar, "11111111-1111-1111-1111-111111111111")
+	assert.True(t, diags.HasError())
+
+	// The new pool is tracked by the resource and the replaced pool is kept in previous_pool_id to be deleted by the next apply.
+	assert.Equal(t, "fr-par/22222222-2222-2222-2222-222222222222", d.Id())
+	assert.Equal(t, "fr-par/11111111-1111-1111-1111-111111111111", d.Get("previous_pool_id"))
+	state := d.State()
+	assert.Equal(t, "gp1_xs", state.Attributes["node_type"])
+	assert.Equal(t, "fr-par/11111111-1111-1111-1111-111111111111", state.Attributes["previous_pool_id"])
+}
@@ -0,0 +1,163 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayRdbACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayRdbACLCreate,
+		ReadContext:   resourceScalewayRdbACLRead,
+		UpdateContext: resourceScalewayRdbACLUpdate,
+		DeleteContext: resourceScalewayRdbACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				Description:  "Instance on which the ACL rules are applied",
+			},
+			"acl_rules": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Order insensitive ACL rules of the instance, the only ones allowed to reach it",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateRdbACLRuleIP,
+							Description:  "The CIDR allowed to reach the instance",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The description of the ACL rule",
+						},
+					},
+				},
+			},
+			// Common
+			"region": regionSchema(),
+		},
+	}
+}
+
+func resourceScalewayRdbACLCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, region, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The region of the instance ID takes precedence over the region of the resource.
+	d.SetId(datasourceNewRegionalizedID(d.Get("instance_id"), region))
+
+	// We call update instead of read as it will take care of setting the rules.
+	return resourceScalewayRdbACLUpdate(ctx, d, meta)
+}
+
+func resourceScalewayRdbACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, instanceID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := rdbAPI.ListInstanceACLRules(&rdb.ListInstanceACLRulesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("instance_id", newRegionalIDString(region, instanceID))
+	_ = d.Set("acl_rules", flattenRdbACLRules(res.Rules))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayRdbACLUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, instanceID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("acl_rules") {
+		// The instance may be in a transient state, e.g. right after its creation
+		_, err = waitRdbInstance(ctx, rdbAPI, region, instanceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = rdbAPI.SetInstanceACLRules(&rdb.SetInstanceACLRulesRequest{
+			Region:     region,
+			InstanceID: instanceID,
+			Rules:      expandRdbACLRules(d.Get("acl_rules")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = waitRdbInstance(ctx, rdbAPI, region, instanceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayRdbACLRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbACLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, instanceID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitRdbInstance(ctx, rdbAPI, region, instanceID)
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// Without any managed rule left, the instance is reachable from any IP again, as after its creation.
+	_, err = rdbAPI.SetInstanceACLRules(&rdb.SetInstanceACLRulesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Rules: []*rdb.ACLRuleRequest{
+			{
+				IP:          expandIPNet(rdbDefaultACLRuleIP),
+				Description: rdbDefaultACLRuleDescription,
+			},
+		},
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	_, err = waitRdbInstance(ctx, rdbAPI, region, instanceID)
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
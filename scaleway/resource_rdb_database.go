@@ -0,0 +1,181 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayRdbDatabase() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayRdbDatabaseCreate,
+		ReadContext:   resourceScalewayRdbDatabaseRead,
+		DeleteContext: resourceScalewayRdbDatabaseDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "Instance on which the database is created",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Database name",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Description: "User that owns the database",
+				Computed:    true,
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Description: "Whether or not the database is managed",
+				Computed:    true,
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Description: "Size of the database",
+				Computed:    true,
+			},
+			// Common
+			"region": regionSchema(),
+		},
+	}
+}
+
+func resourceScalewayRdbDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceID := d.Get("instance_id").(string)
+	createReq := &rdb.CreateDatabaseRequest{
+		Region:     region,
+		InstanceID: expandID(instanceID),
+		Name:       d.Get("name").(string),
+	}
+
+	res, err := rdbAPI.CreateDatabase(createReq, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resourceScalewayRdbDatabaseID(region, expandID(instanceID), res.Name))
+
+	return resourceScalewayRdbDatabaseRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, _, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	region, instanceID, databaseName, err := resourceScalewayRdbDatabaseParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	database, err := getRdbDatabase(ctx, rdbAPI, region, instanceID, databaseName)
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if database == nil {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("instance_id", newRegionalID(region, instanceID).String())
+	_ = d.Set("name", database.Name)
+	_ = d.Set("owner", database.Owner)
+	_ = d.Set("managed", database.Managed)
+	_ = d.Set("size", database.Size.String())
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayRdbDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, _, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	region, instanceID, databaseName, err := resourceScalewayRdbDatabaseParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = rdbAPI.DeleteDatabase(&rdb.DeleteDatabaseRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       databaseName,
+	}, scw.WithContext(ctx))
+
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// getRdbDatabase returns the database with the given name, or nil if the instance has no such database.
+func getRdbDatabase(ctx context.Context, rdbAPI *rdb.API, region scw.Region, instanceID string, databaseName string) (*rdb.Database, error) {
+	res, err := rdbAPI.ListDatabases(&rdb.ListDatabasesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		Name:       &databaseName,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The name filter of the API is not an exact match.
+	for _, database := range res.Databases {
+		if database.Name == databaseName {
+			return database, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Build the resource identifier
+// The resource identifier format is "Region/InstanceId/DatabaseName"
+func resourceScalewayRdbDatabaseID(region scw.Region, instanceID string, databaseName string) (resourceID string) {
+	return fmt.Sprintf("%s/%s/%s", region, instanceID, databaseName)
+}
+
+// Extract region, instance ID and database name from the resource identifier.
+// The resource identifier format is "Region/InstanceId/DatabaseName"
+func resourceScalewayRdbDatabaseParseID(resourceID string) (region scw.Region, instanceID string, databaseName string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 3 {
+		return "", "", "", fmt.Errorf("can't parse database resource id: %s", resourceID)
+	}
+	region, err = scw.ParseRegion(idParts[0])
+	if err != nil {
+		return "", "", "", err
+	}
+	return region, idParts[1], idParts[2], nil
+}
@@ -0,0 +1,237 @@
+package scaleway
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayRdbDatabaseBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayRdbDatabaseBackupCreate,
+		ReadContext:   resourceScalewayRdbDatabaseBackupRead,
+		UpdateContext: resourceScalewayRdbDatabaseBackupUpdate,
+		DeleteContext: resourceScalewayRdbDatabaseBackupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validationUUIDorUUIDWithLocality(),
+				Description:  "Instance on which the database is",
+			},
+			"database_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the database to back up",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the backup",
+			},
+			// The expiration date cannot be cleared, it is kept when removed from the configuration.
+			"expires_at": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: diffSuppressFuncTimeRFC3339,
+				Description:      "Expiration date of the backup (Format ISO 8601)",
+			},
+			"export": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Export the backup to get a download URL",
+			},
+			// Computed
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the backup",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the backup, in bytes",
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the instance of the backup",
+			},
+			"download_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "URL to download the backup from, when the backup is exported",
+			},
+			"download_url_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the download URL",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the backup (Format ISO 8601)",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Last update date of the backup (Format ISO 8601)",
+			},
+			// Common
+			"region": regionSchema(),
+		},
+	}
+}
+
+func resourceScalewayRdbDatabaseBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := rdbAPI.CreateDatabaseBackup(&rdb.CreateDatabaseBackupRequest{
+		Region:       region,
+		InstanceID:   expandID(d.Get("instance_id")),
+		DatabaseName: d.Get("database_name").(string),
+		Name:         expandOrGenerateString(d.Get("name"), "backup"),
+		ExpiresAt:    expandTimePtr(d.Get("expires_at")),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(newRegionalIDString(region, res.ID))
+
+	_, err = waitRdbDatabaseBackup(ctx, rdbAPI, region, res.ID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.Get("export").(bool) {
+		err = exportRdbDatabaseBackup(ctx, rdbAPI, region, res.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayRdbDatabaseBackupRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbDatabaseBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := rdbAPI.GetDatabaseBackup(&rdb.GetDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_ = d.Set("instance_id", newRegionalIDString(region, res.InstanceID))
+	_ = d.Set("database_name", res.DatabaseName)
+	_ = d.Set("name", res.Name)
+	_ = d.Set("expires_at", flattenTime(res.ExpiresAt))
+	_ = d.Set("status", res.Status)
+	if res.Size != nil {
+		_ = d.Set("size", int(*res.Size))
+	}
+	_ = d.Set("instance_name", res.InstanceName)
+	_ = d.Set("download_url", flattenStringPtr(res.DownloadURL))
+	_ = d.Set("download_url_expires_at", flattenTime(res.DownloadURLExpiresAt))
+	_ = d.Set("created_at", flattenTime(res.CreatedAt))
+	_ = d.Set("updated_at", flattenTime(res.UpdatedAt))
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayRdbDatabaseBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	req := &rdb.UpdateDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: ID,
+	}
+
+	if d.HasChange("name") {
+		req.Name = expandStringPtr(d.Get("name"))
+	}
+	if d.HasChange("expires_at") {
+		req.ExpiresAt = expandTimePtr(d.Get("expires_at"))
+	}
+
+	if req.Name != nil || req.ExpiresAt != nil {
+		_, err = rdbAPI.UpdateDatabaseBackup(req, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("export") && d.Get("export").(bool) {
+		err = exportRdbDatabaseBackup(ctx, rdbAPI, region, ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayRdbDatabaseBackupRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbDatabaseBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, ID, err := rdbAPIWithRegionAndID(meta, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// We first wait in case the backup is in a transient state
+	_, err = rdbAPI.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: ID,
+		Timeout:          scw.TimeDurationPtr(defaultRdbInstanceTimeout),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	_, err = rdbAPI.DeleteDatabaseBackup(&rdb.DeleteDatabaseBackupRequest{
+		Region:           region,
+		DatabaseBackupID: ID,
+	}, scw.WithContext(ctx))
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -56,6 +56,31 @@ func resourceScalewayRdbInstance() *schema.Resource {
 				Default:     false,
 				Description: "Disable automated backup for the database instance",
 			},
+			"backup_schedule": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Computed:    true,
+				Description: "Schedule of the automated backups of the database instance",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Time between two automated backups, in hours",
+						},
+						"retention": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Time the automated backups are kept, in days",
+						},
+					},
+				},
+			},
 			"user_name": {
 				Type:        schema.TypeString,
 				ForceNew:    true,
@@ -204,6 +229,24 @@ func resourceScalewayRdbInstanceCreate(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
+	// The backup schedule can only be set once the instance is created
+	if _, ok := d.GetOk("backup_schedule"); ok {
+		_, err = waitRdbInstance(ctx, rdbAPI, region, res.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		_, err = rdbAPI.UpdateInstance(&rdb.UpdateInstanceRequest{
+			Region:                  region,
+			InstanceID:              res.ID,
+			BackupScheduleFrequency: expandUint32Ptr(d.Get("backup_schedule.0.frequency")),
+			BackupScheduleRetention: expandUint32Ptr(d.Get("backup_schedule.0.retention")),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceScalewayRdbInstanceRead(ctx, d, meta)
 }
 
@@ -230,6 +273,7 @@ func resourceScalewayRdbInstanceRead(ctx context.Context, d *schema.ResourceData
 	_ = d.Set("engine", res.Engine)
 	_ = d.Set("is_ha_cluster", res.IsHaCluster)
 	_ = d.Set("disable_backup", res.BackupSchedule.Disabled)
+	_ = d.Set("backup_schedule", flattenRdbBackupSchedule(res.BackupSchedule))
 	_ = d.Set("user_name", d.Get("user_name").(string)) // user name and
 	_ = d.Set("password", d.Get("password").(string))   // password are immutable
 	_ = d.Set("tags", res.Tags)
@@ -286,6 +330,12 @@ func resourceScalewayRdbInstanceUpdate(ctx context.Context, d *schema.ResourceDa
 	if d.HasChange("disable_backup") {
 		req.IsBackupScheduleDisabled = scw.BoolPtr(d.Get("disable_backup").(bool))
 	}
+	if d.HasChange("backup_schedule.0.frequency") {
+		req.BackupScheduleFrequency = expandUint32Ptr(d.Get("backup_schedule.0.frequency"))
+	}
+	if d.HasChange("backup_schedule.0.retention") {
+		req.BackupScheduleRetention = expandUint32Ptr(d.Get("backup_schedule.0.retention"))
+	}
 
 	if d.HasChange("tags") {
 		req.Tags = scw.StringsPtr(expandStrings(d.Get("tags")))
@@ -0,0 +1,209 @@
+package scaleway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func resourceScalewayRdbPrivilege() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceScalewayRdbPrivilegeCreate,
+		ReadContext:   resourceScalewayRdbPrivilegeRead,
+		UpdateContext: resourceScalewayRdbPrivilegeUpdate,
+		DeleteContext: resourceScalewayRdbPrivilegeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Default: schema.DefaultTimeout(defaultRdbInstanceTimeout),
+		},
+		SchemaVersion: 0,
+		Schema: map[string]*schema.Schema{
+			"instance_id": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validationUUIDorUUIDWithLocality(),
+				DiffSuppressFunc: diffSuppressFuncLocality,
+				Description:      "Instance on which the privilege is set",
+			},
+			"user_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "User name",
+			},
+			"database_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Database name",
+			},
+			"permission": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Privilege of the user on the database",
+				ValidateFunc: validation.StringInSlice([]string{
+					rdb.PermissionReadonly.String(),
+					rdb.PermissionReadwrite.String(),
+					rdb.PermissionAll.String(),
+					rdb.PermissionNone.String(),
+				}, false),
+			},
+			// Common
+			"region": regionSchema(),
+		},
+	}
+}
+
+func resourceScalewayRdbPrivilegeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, region, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	instanceID := expandID(d.Get("instance_id"))
+	createReq := &rdb.SetPrivilegeRequest{
+		Region:       region,
+		InstanceID:   instanceID,
+		DatabaseName: d.Get("database_name").(string),
+		UserName:     d.Get("user_name").(string),
+		Permission:   rdb.Permission(d.Get("permission").(string)),
+	}
+
+	res, err := rdbAPI.SetPrivilege(createReq, scw.WithContext(ctx))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resourceScalewayRdbPrivilegeID(region, instanceID, res.DatabaseName, res.UserName))
+
+	return resourceScalewayRdbPrivilegeRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbPrivilegeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, _, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	region, instanceID, databaseName, userName, err := resourceScalewayRdbPrivilegeParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := rdbAPI.ListPrivileges(&rdb.ListPrivilegesRequest{
+		Region:       region,
+		InstanceID:   instanceID,
+		DatabaseName: &databaseName,
+		UserName:     &userName,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		if is404Error(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	var privilege *rdb.Privilege
+	for _, p := range res.Privileges {
+		if p.DatabaseName == databaseName && p.UserName == userName {
+			privilege = p
+			break
+		}
+	}
+	// The user or the database has been deleted.
+	if privilege == nil {
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("instance_id", newRegionalID(region, instanceID).String())
+	_ = d.Set("database_name", privilege.DatabaseName)
+	_ = d.Set("user_name", privilege.UserName)
+	_ = d.Set("permission", privilege.Permission)
+	_ = d.Set("region", region)
+
+	return nil
+}
+
+func resourceScalewayRdbPrivilegeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, _, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	region, instanceID, databaseName, userName, err := resourceScalewayRdbPrivilegeParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChange("permission") {
+		_, err = rdbAPI.SetPrivilege(&rdb.SetPrivilegeRequest{
+			Region:       region,
+			InstanceID:   instanceID,
+			DatabaseName: databaseName,
+			UserName:     userName,
+			Permission:   rdb.Permission(d.Get("permission").(string)),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceScalewayRdbPrivilegeRead(ctx, d, meta)
+}
+
+func resourceScalewayRdbPrivilegeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rdbAPI, _, err := rdbAPIWithRegion(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	region, instanceID, databaseName, userName, err := resourceScalewayRdbPrivilegeParseID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Privileges cannot be deleted, the user is left without any permission on the database.
+	_, err = rdbAPI.SetPrivilege(&rdb.SetPrivilegeRequest{
+		Region:       region,
+		InstanceID:   instanceID,
+		DatabaseName: databaseName,
+		UserName:     userName,
+		Permission:   rdb.PermissionNone,
+	}, scw.WithContext(ctx))
+
+	if err != nil && !is404Error(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// Build the resource identifier
+// The resource identifier format is "Region/InstanceId/DatabaseName/UserName"
+func resourceScalewayRdbPrivilegeID(region scw.Region, instanceID string, databaseName string, userName string) (resourceID string) {
+	return fmt.Sprintf("%s/%s/%s/%s", region, instanceID, databaseName, userName)
+}
+
+// Extract region, instance ID, database name and user name from the resource identifier.
+// The resource identifier format is "Region/InstanceId/DatabaseName/UserName"
+func resourceScalewayRdbPrivilegeParseID(resourceID string) (region scw.Region, instanceID string, databaseName string, userName string, err error) {
+	idParts := strings.Split(resourceID, "/")
+	if len(idParts) != 4 {
+		return "", "", "", "", fmt.Errorf("can't parse privilege resource id: %s", resourceID)
+	}
+	region, err = scw.ParseRegion(idParts[0])
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return region, idParts[1], idParts[2], idParts[3], nil
+}